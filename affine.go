@@ -0,0 +1,68 @@
+package parametric2d
+
+import (
+	"math"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// Affine is a 2D affine transformation. Using the same convention as the
+// SVG matrix(a b c d e f) transform, it maps (x, y) to
+// (A*x + C*y + E, B*x + D*y + F).
+type Affine struct {
+	A, B, C, D, E, F float64
+}
+
+// IdentityAffine is the transformation that leaves points unchanged.
+var IdentityAffine = Affine{A: 1, D: 1}
+
+// NewTranslation returns a transformation that moves points by (tx, ty).
+func NewTranslation(tx, ty float64) Affine {
+	return Affine{A: 1, D: 1, E: tx, F: ty}
+}
+
+// NewScaling returns a transformation that scales points about the
+// origin. A negative factor mirrors along that axis.
+func NewScaling(sx, sy float64) Affine {
+	return Affine{A: sx, D: sy}
+}
+
+// NewRotation returns a transformation that rotates points about the
+// origin by 'deg' degrees counter-clockwise.
+func NewRotation(deg float64) Affine {
+	sin, cos := math.Sincos(deg * math.Pi / 180.0)
+	return Affine{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// Mul returns the transformation that applies 'n' and then 'm'.
+func (m Affine) Mul(n Affine) Affine {
+	return Affine{
+		A: m.A*n.A + m.C*n.B,
+		B: m.B*n.A + m.D*n.B,
+		C: m.A*n.C + m.C*n.D,
+		D: m.B*n.C + m.D*n.D,
+		E: m.A*n.E + m.C*n.F + m.E,
+		F: m.B*n.E + m.D*n.F + m.F,
+	}
+}
+
+// Apply returns the transformed point.
+func (m Affine) Apply(p vec2.T) vec2.T {
+	return vec2.T{m.A*p[0] + m.C*p[1] + m.E, m.B*p[0] + m.D*p[1] + m.F}
+}
+
+// applyVector returns the transformed direction, ignoring the translation.
+func (m Affine) applyVector(v vec2.T) vec2.T {
+	return vec2.T{m.A*v[0] + m.C*v[1], m.B*v[0] + m.D*v[1]}
+}
+
+// Det returns the determinant of the linear part of the transformation,
+// which is negative for transformations that mirror.
+func (m Affine) Det() float64 {
+	return m.A*m.D - m.B*m.C
+}
+
+// IsMirror returns true if the transformation reverses orientation.
+func (m Affine) IsMirror() bool {
+	return m.Det() < 0
+}
@@ -0,0 +1,117 @@
+package parametric2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+func TestAffine(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Affine
+		p    vec2.T
+		want vec2.T
+	}{
+		{"identity", IdentityAffine, vec2.T{2, 3}, vec2.T{2, 3}},
+		{"translation", NewTranslation(1, -1), vec2.T{2, 3}, vec2.T{3, 2}},
+		{"scaling", NewScaling(2, -1), vec2.T{2, 3}, vec2.T{4, -3}},
+		{"rotation", NewRotation(90), vec2.T{2, 3}, vec2.T{-3, 2}},
+		// Scale first, then translate.
+		{"translation*scaling", NewTranslation(1, 1).Mul(NewScaling(2, 2)), vec2.T{2, 3}, vec2.T{5, 7}},
+		// Translate first, then scale.
+		{"scaling*translation", NewScaling(2, 2).Mul(NewTranslation(1, 1)), vec2.T{2, 3}, vec2.T{6, 8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Apply(tt.p); !vecNear(got, tt.want, 1e-12) {
+				t.Errorf("Apply(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+	if NewRotation(30).IsMirror() || !NewScaling(-1, 1).IsMirror() {
+		t.Errorf("IsMirror failed")
+	}
+}
+
+func TestSegmentTransform(t *testing.T) {
+	transforms := map[string]Affine{
+		"translate": NewTranslation(3, -2),
+		"rotate":    NewRotation(33),
+		"scale":     NewScaling(2, 0.5),
+		"mirror":    NewScaling(-1, 1),
+		"skew":      {A: 1, B: 0.3, C: 0.7, D: 1, E: 1, F: 2},
+		"mixed":     NewTranslation(5, 5).Mul(NewScaling(-2, 3)).Mul(NewRotation(-70)),
+	}
+	segs := map[string]T{
+		"line":      NewLine(vec2.T{1, 2}, vec2.T{4, -1}),
+		"curve":     NewCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, -2}, vec2.T{3, 0}),
+		"quadcurve": NewQuadCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0}),
+		"circle":    NewArc(vec2.T{1, 1}, 2, 2, 0, 10, 250),
+		"ellipse":   NewArc(vec2.T{-1, 2}, 3, 1, 40, -30, -200),
+	}
+	for mName, m := range transforms {
+		for sName, seg := range segs {
+			t.Run(sName+"/"+mName, func(t *testing.T) {
+				got := seg.Transform(m)
+				for i := 0; i <= 20; i++ {
+					u := float64(i) / 20
+					if p, want := got.At(u), m.Apply(seg.At(u)); !vecNear(p, want, 1e-9) {
+						t.Fatalf("At(%v) = %v, want %v", u, p, want)
+					}
+				}
+				// The bbox must be tight around the transformed segment.
+				bbox := got.BBox()
+				want := vec2.Rect{Min: got.At(0), Max: got.At(0)}
+				for i := 0; i <= 10000; i++ {
+					p := got.At(float64(i) / 10000)
+					want.Min = vec2.Min(&want.Min, &p)
+					want.Max = vec2.Max(&want.Max, &p)
+				}
+				if !vecNear(bbox.Min, want.Min, 1e-4) || !vecNear(bbox.Max, want.Max, 1e-4) {
+					t.Errorf("BBox = %v, want %v", bbox, want)
+				}
+			})
+		}
+	}
+}
+
+func TestSubPathTransform_Mirror(t *testing.T) {
+	sp := circleSubPath(vec2.T{1, 1}, 2, true)
+	sp.AutoFlipNormals()
+	m := NewScaling(-1, 1)
+	got := sp.Transform(m)
+	if got.FlipNormals == sp.FlipNormals {
+		t.Errorf("FlipNormals = %v, want it toggled", got.FlipNormals)
+	}
+	if got.Orientation() != Clockwise {
+		t.Errorf("Orientation = %v, want Clockwise", got.Orientation())
+	}
+	if !normalsPointInward(got, m.Apply(vec2.T{1, 1})) {
+		t.Errorf("normals do not point inward after mirroring")
+	}
+	if sp.Segments[0].At(0) == got.Segments[0].At(0) {
+		t.Errorf("Transform modified the original SubPath")
+	}
+}
+
+func TestPathTransform(t *testing.T) {
+	p := mustParseSVGPath(t, "M0 0h10v10h-10z M2 2h6v6h-6z")
+	p.FillRule = NonZero
+	q := p.Transform(NewTranslation(100, 0).Mul(NewScaling(-2, 3)))
+	if q.FillRule != NonZero {
+		t.Errorf("FillRule = %v, want NonZero", q.FillRule)
+	}
+	bbox := q.BBox()
+	if want := (vec2.Rect{Min: vec2.T{80, 0}, Max: vec2.T{100, 30}}); bbox != want {
+		t.Errorf("BBox = %v, want %v", bbox, want)
+	}
+	tris, err := q.Wall(1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := projectedArea(tris), 600.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("floor area = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,350 @@
+package parametric2d
+
+import (
+	"math"
+
+	"github.com/gmlewis/go-poly2tri"
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// Arc represents a 2D circular or elliptical arc and implements interface T.
+type Arc struct {
+	center   vec2.T
+	rx, ry   float64
+	rotation float64 // radians
+	start    float64 // radians
+	sweep    float64 // radians
+	bbox     vec2.Rect
+}
+
+// NewArc returns a new 2D elliptical arc centered at 'center' with radii
+// 'rx' and 'ry'. The ellipse's x axis is rotated by 'rotation' degrees
+// and the arc starts at parametric angle 'start' degrees and sweeps
+// through 'sweep' degrees (positive is counter-clockwise).
+// Sweeps larger than a full turn are clamped to 360 degrees.
+func NewArc(center vec2.T, rx, ry, rotation, start, sweep float64) Arc {
+	sweep = math.Max(-360, math.Min(360, sweep))
+	s := Arc{
+		center:   center,
+		rx:       math.Abs(rx),
+		ry:       math.Abs(ry),
+		rotation: rotation * math.Pi / 180.0,
+		start:    start * math.Pi / 180.0,
+		sweep:    sweep * math.Pi / 180.0,
+	}
+	s.bbox = s.computeBBox()
+	return s
+}
+
+// NewSVGArc returns a new 2D arc using the SVG endpoint parameterization,
+// where the arc goes from 'p0' to 'p1' on an ellipse with radii 'rx' and
+// 'ry' whose x axis is rotated by 'rotation' degrees. 'largeArc' and
+// 'sweep' select one of the four candidate arcs as in the SVG "A" command.
+//
+// Following the SVG implementation notes, radii that are too small are
+// scaled up, a zero radius results in a Line, and identical endpoints
+// result in nil (the arc is omitted).
+func NewSVGArc(p0 vec2.T, rx, ry, rotation float64, largeArc, sweep bool, p1 vec2.T) T {
+	if p0 == p1 {
+		return nil
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		return NewLine(p0, p1)
+	}
+	phi := rotation * math.Pi / 180.0
+	cosPhi, sinPhi := math.Cos(phi), math.Sin(phi)
+	dx, dy := 0.5*(p0[0]-p1[0]), 0.5*(p0[1]-p1[1])
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	if lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry); lambda > 1 {
+		f := math.Sqrt(lambda)
+		rx *= f
+		ry *= f
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := math.Sqrt(math.Max(0, num/den))
+	if largeArc == sweep {
+		coef = -coef
+	}
+	cx1 := coef * rx * y1 / ry
+	cy1 := -coef * ry * x1 / rx
+	center := vec2.T{
+		cosPhi*cx1 - sinPhi*cy1 + 0.5*(p0[0]+p1[0]),
+		sinPhi*cx1 + cosPhi*cy1 + 0.5*(p0[1]+p1[1]),
+	}
+
+	u := vec2.T{(x1 - cx1) / rx, (y1 - cy1) / ry}
+	v := vec2.T{(-x1 - cx1) / rx, (-y1 - cy1) / ry}
+	theta := math.Atan2(u[1], u[0])
+	delta := math.Atan2(u[0]*v[1]-u[1]*v[0], vec2.Dot(&u, &v))
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	s := Arc{
+		center:   center,
+		rx:       rx,
+		ry:       ry,
+		rotation: phi,
+		start:    theta,
+		sweep:    delta,
+	}
+	s.bbox = s.computeBBox()
+	return s
+}
+
+// computeBBox evaluates the arc at its endpoints and at every
+// axis-aligned extremum of the ellipse that lies within the sweep.
+func (s Arc) computeBBox() vec2.Rect {
+	p0 := s.At(0)
+	p1 := s.At(1)
+	ll := vec2.Min(&p0, &p1)
+	ur := vec2.Max(&p0, &p1)
+	cosPhi, sinPhi := math.Cos(s.rotation), math.Sin(s.rotation)
+	thetaX := math.Atan2(-s.ry*sinPhi, s.rx*cosPhi)
+	thetaY := math.Atan2(s.ry*cosPhi, s.rx*sinPhi)
+	for _, theta := range []float64{thetaX, thetaX + math.Pi, thetaY, thetaY + math.Pi} {
+		if t, ok := s.angleToT(theta); ok {
+			v := s.At(t)
+			ll = vec2.Min(&ll, &v)
+			ur = vec2.Max(&ur, &v)
+		}
+	}
+	return vec2.Rect{Min: ll, Max: ur}
+}
+
+// angleToT returns the parametric 't' value of the given ellipse angle
+// (in radians) and whether it lies within the arc's sweep.
+func (s Arc) angleToT(theta float64) (float64, bool) {
+	if s.sweep == 0 {
+		return 0, false
+	}
+	d := theta - s.start
+	if s.sweep < 0 {
+		d = -d
+	}
+	d = math.Mod(d, 2*math.Pi)
+	if d < 0 {
+		d += 2 * math.Pi
+	}
+	t := d / math.Abs(s.sweep)
+	return t, t <= 1
+}
+
+// Center returns the center of the Arc's ellipse.
+func (s Arc) Center() vec2.T {
+	return s.center
+}
+
+// Radii returns the x and y radii of the Arc's ellipse.
+func (s Arc) Radii() (rx, ry float64) {
+	return s.rx, s.ry
+}
+
+// Rotation returns the rotation of the Arc's ellipse in degrees.
+func (s Arc) Rotation() float64 {
+	return s.rotation * 180.0 / math.Pi
+}
+
+// StartAngle returns the parametric starting angle of the Arc in degrees.
+func (s Arc) StartAngle() float64 {
+	return s.start * 180.0 / math.Pi
+}
+
+// SweepAngle returns the parametric sweep of the Arc in degrees.
+func (s Arc) SweepAngle() float64 {
+	return s.sweep * 180.0 / math.Pi
+}
+
+// BBox returns the minimum bounding box of the Arc.
+func (s Arc) BBox() vec2.Rect {
+	return s.bbox
+}
+
+// At returns the point on the Arc at the given position (0 <= t <= 1).
+func (s Arc) At(t float64) vec2.T {
+	theta := s.start + t*s.sweep
+	v := vec2.T{s.rx * math.Cos(theta), s.ry * math.Sin(theta)}
+	v.Rotate(s.rotation)
+	return vec2.Add(&s.center, &v)
+}
+
+// Tangent returns the tangent to the Arc
+// at the given position (0 <= t <= 1).
+func (s Arc) Tangent(t float64) vec2.T {
+	theta := s.start + t*s.sweep
+	v := vec2.T{-s.rx * math.Sin(theta), s.ry * math.Cos(theta)}
+	v.Rotate(s.rotation)
+	return *v.Scale(s.sweep)
+}
+
+// NTangent returns the normalized tangent to the Arc
+// at the given position (0 <= t <= 1).
+func (s Arc) NTangent(t float64) vec2.T {
+	v := s.Tangent(t)
+	return *v.Normalize()
+}
+
+// Normal returns the normal to the Arc
+// at the given position (0 <= t <= 1).
+func (s Arc) Normal(t float64) vec2.T {
+	v := s.Tangent(t)
+	return *v.Rotate90DegLeft()
+}
+
+// NNormal returns the normalized normal to the Arc
+// at the given position (0 <= t <= 1).
+func (s Arc) NNormal(t float64) vec2.T {
+	v := s.Normal(t)
+	return *v.Normalize()
+}
+
+// Subdivide returns the parametric 't' values along the arc
+// such that the tangent between two points never exceeds `maxDegrees`.
+// The arc is divided where its tangent has turned by equal steps, so
+// (unlike the other curves) it is never limited to a minimum slice width.
+func (s Arc) Subdivide(maxDegrees float64) []float64 {
+	maxRadians := math.Abs(maxDegrees * math.Pi / 180.0)
+	if maxRadians == 0 || s.rx == 0 || s.ry == 0 {
+		return subdivide(s, maxDegrees)
+	}
+	theta0, theta1 := s.start, s.start+s.sweep
+	phi0, phi1 := s.tangentAngle(theta0), s.tangentAngle(theta1)
+	n := int(math.Max(2, math.Ceil(math.Abs(phi1-phi0)/maxRadians)))
+	ts := make([]float64, n+1)
+	for i := 1; i < n; i++ {
+		phi := phi0 + (phi1-phi0)*float64(i)/float64(n)
+		// The tangent at parametric angle theta lies within a quarter turn
+		// of theta+pi/2, which picks out theta in (phi-pi, phi).
+		theta := math.Atan2(-math.Cos(phi)/s.rx, math.Sin(phi)/s.ry)
+		theta += 2 * math.Pi * math.Ceil((phi-math.Pi-theta)/(2*math.Pi))
+		ts[i] = clamp01((theta - s.start) / s.sweep)
+	}
+	ts[n] = 1
+	return ts
+}
+
+// tangentAngle returns the direction (in radians, relative to the
+// ellipse's x axis) of the tangent at parametric angle 'theta', which
+// increases continuously with theta.
+func (s Arc) tangentAngle(theta float64) float64 {
+	sin, cos := math.Sincos(theta)
+	return theta + 0.5*math.Pi + math.Atan2((s.rx-s.ry)*sin*cos, s.rx*sin*sin+s.ry*cos*cos)
+}
+
+// Wall extrudes an arc into a 3D wall. `maxDegrees` determines the smoothness
+// of the wall along the arc.
+func (s Arc) Wall(height, maxDegrees float64, flipNormals bool) ([]Triangle3D, poly2tri.PointArray, error) {
+	return wall(s, s.Subdivide(maxDegrees), height, flipNormals)
+}
+
+// Bevel returns a 3D beveled object based on the provided arc.
+func (s Arc) Bevel(height, offset, deg, maxDegrees float64, flipNormals bool, prevNN, nextNN *vec2.T) ([]Triangle3D, poly2tri.PointArray, error) {
+	return bevel(s, s.Subdivide(maxDegrees), height, offset, deg, flipNormals, prevNN, nextNN)
+}
+
+// IsLine is false for type Arc.
+func (s Arc) IsLine() bool {
+	return false
+}
+
+// Reverse returns the Arc swept in the opposite direction.
+func (s Arc) Reverse() T {
+	r := s
+	r.start = s.start + s.sweep
+	r.sweep = -s.sweep
+	return r
+}
+
+// Split divides the Arc at position 't'.
+func (s Arc) Split(t float64) (T, T) {
+	return s.Sub(0, t), s.Sub(t, 1)
+}
+
+// Sub returns the part of the Arc between positions 't0' and 't1'.
+func (s Arc) Sub(t0, t1 float64) T {
+	r := s
+	r.start = s.start + t0*s.sweep
+	r.sweep = (t1 - t0) * s.sweep
+	r.bbox = r.computeBBox()
+	return r
+}
+
+// Transform returns the Arc transformed by 'm'. The image of an ellipse
+// under an affine transformation is another ellipse, whose radii and
+// rotation are found from the singular value decomposition of the
+// combined linear map. Mirror transformations reverse the sweep.
+func (s Arc) Transform(m Affine) T {
+	// K maps the unit circle onto the transformed ellipse:
+	// K = m * Rotate(rotation) * Scale(rx, ry).
+	k := m.Mul(NewRotation(s.rotation * 180.0 / math.Pi)).Mul(NewScaling(s.rx, s.ry))
+	// Decompose K = Rotate(phi) * Scale(s1, s2) * Rotate(theta), where s2
+	// is negative when K mirrors.
+	e, f := 0.5*(k.A+k.D), 0.5*(k.A-k.D)
+	g, h := 0.5*(k.B+k.C), 0.5*(k.B-k.C)
+	q, r := math.Hypot(e, h), math.Hypot(f, g)
+	a1, a2 := math.Atan2(g, f), math.Atan2(h, e)
+	theta, phi := 0.5*(a2-a1), 0.5*(a2+a1)
+	s1, s2 := q+r, q-r
+
+	t := Arc{
+		center:   m.Apply(s.center),
+		rx:       s1,
+		ry:       math.Abs(s2),
+		rotation: phi,
+		start:    s.start + theta,
+		sweep:    s.sweep,
+	}
+	if s2 < 0 {
+		// Scale(s1, s2) = Scale(s1, |s2|) * Scale(1, -1), and the mirror
+		// negates the parametric angle.
+		t.start, t.sweep = -t.start, -t.sweep
+	}
+	t.bbox = t.computeBBox()
+	return t
+}
+
+// ClosestPoint returns the position along the Arc nearest to 'p' and its
+// distance from 'p'. Circular arcs are solved exactly; elliptical arcs
+// are solved numerically.
+func (s Arc) ClosestPoint(p vec2.T) (float64, float64) {
+	if s.rx != s.ry {
+		return closestPointSampled(s, p)
+	}
+	ts := []float64{0, 1}
+	v := vec2.Sub(&p, &s.center)
+	if !v.IsZero() {
+		theta := math.Atan2(v[1], v[0]) - s.rotation
+		if t, ok := s.angleToT(theta); ok {
+			ts = append(ts, t)
+		}
+	}
+	return closestOf(s, p, ts)
+}
+
+// Length returns the arc length of the Arc.
+func (s Arc) Length() float64 {
+	if s.rx == s.ry {
+		return s.rx * math.Abs(s.sweep)
+	}
+	return arcLength(s, 0, 1, DefaultLengthTolerance)
+}
+
+// AtLength returns the position (0 <= t <= 1) at the given arc length
+// along the Arc.
+func (s Arc) AtLength(l float64) float64 {
+	total := s.Length()
+	if s.rx == s.ry {
+		if total == 0 {
+			return 0
+		}
+		return math.Max(0, math.Min(1, l/total))
+	}
+	return tAtLength(s, total, l, DefaultLengthTolerance)
+}
@@ -0,0 +1,206 @@
+package parametric2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+func TestArc_interface(t *testing.T) {
+	var arc T = NewArc(vec2.T{0, 0}, 1, 1, 0, 0, 90)
+	if arc == nil {
+		t.Errorf("arc does not implement interface T")
+	}
+}
+
+func vecNear(a, b vec2.T, e float64) bool {
+	return math.Abs(a[0]-b[0]) <= e && math.Abs(a[1]-b[1]) <= e
+}
+
+func TestArcAt(t *testing.T) {
+	v := NewArc(vec2.T{1, 1}, 2, 2, 0, 0, 90)
+	tests := []struct {
+		t    float64
+		want vec2.T
+	}{
+		{0, vec2.T{3, 1}},
+		{0.5, vec2.T{1 + math.Sqrt(2), 1 + math.Sqrt(2)}},
+		{1, vec2.T{1, 3}},
+	}
+	for _, tt := range tests {
+		if got := v.At(tt.t); !vecNear(got, tt.want, 1e-15) {
+			t.Errorf("At(%v) failed: got %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestArcTangentAndNormal(t *testing.T) {
+	v := NewArc(vec2.T{0, 0}, 1, 1, 0, 0, 90)
+	if got, want := v.NTangent(0), (vec2.T{0, 1}); !vecNear(got, want, 1e-15) {
+		t.Errorf("NTangent failed: got %v, want %v", got, want)
+	}
+	// A counter-clockwise arc's left normal points toward the center.
+	if got, want := v.NNormal(0), (vec2.T{-1, 0}); !vecNear(got, want, 1e-15) {
+		t.Errorf("NNormal failed: got %v, want %v", got, want)
+	}
+	w := NewArc(vec2.T{0, 0}, 1, 1, 0, 90, -90)
+	if got, want := w.NNormal(1), (vec2.T{1, 0}); !vecNear(got, want, 1e-15) {
+		t.Errorf("clockwise NNormal failed: got %v, want %v", got, want)
+	}
+}
+
+func TestArcBBox(t *testing.T) {
+	v := NewArc(vec2.T{0, 0}, 1, 1, 0, -45, 90)
+	want := vec2.Rect{Min: vec2.T{math.Sqrt(0.5), -math.Sqrt(0.5)}, Max: vec2.T{1, math.Sqrt(0.5)}}
+	got := v.BBox()
+	if !vecNear(got.Min, want.Min, 1e-15) || !vecNear(got.Max, want.Max, 1e-15) {
+		t.Errorf("BBox failed: got %v, want %v", got, want)
+	}
+}
+
+func TestArcBBox_RotatedEllipse(t *testing.T) {
+	v := NewArc(vec2.T{1, 2}, 3, 1, 30, 10, 300)
+	got := v.BBox()
+	want := vec2.Rect{Min: v.At(0), Max: v.At(0)}
+	for i := 0; i <= 10000; i++ {
+		p := v.At(float64(i) / 10000)
+		want.Min = vec2.Min(&want.Min, &p)
+		want.Max = vec2.Max(&want.Max, &p)
+	}
+	if !vecNear(got.Min, want.Min, 1e-6) || !vecNear(got.Max, want.Max, 1e-6) {
+		t.Errorf("BBox failed: got %v, want %v", got, want)
+	}
+}
+
+func TestNewSVGArc(t *testing.T) {
+	tests := []struct {
+		name            string
+		largeArc, sweep bool
+		wantCenter      vec2.T
+		wantSweep       float64
+	}{
+		{"small ccw", false, true, vec2.T{0, 1}, 90},
+		{"small cw", false, false, vec2.T{1, 0}, -90},
+		{"large ccw", true, true, vec2.T{1, 0}, 270},
+		{"large cw", true, false, vec2.T{0, 1}, -270},
+	}
+	p0, p1 := vec2.T{0, 0}, vec2.T{1, 1}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := NewSVGArc(p0, 1, 1, 0, tt.largeArc, tt.sweep, p1).(Arc)
+			if !ok {
+				t.Fatalf("NewSVGArc did not return an Arc")
+			}
+			if got := v.Center(); !vecNear(got, tt.wantCenter, 1e-12) {
+				t.Errorf("Center = %v, want %v", got, tt.wantCenter)
+			}
+			if got := v.SweepAngle(); math.Abs(got-tt.wantSweep) > 1e-9 {
+				t.Errorf("SweepAngle = %v, want %v", got, tt.wantSweep)
+			}
+			if got := v.At(0); !vecNear(got, p0, 1e-12) {
+				t.Errorf("At(0) = %v, want %v", got, p0)
+			}
+			if got := v.At(1); !vecNear(got, p1, 1e-12) {
+				t.Errorf("At(1) = %v, want %v", got, p1)
+			}
+		})
+	}
+}
+
+func TestNewSVGArc_Degenerate(t *testing.T) {
+	if got := NewSVGArc(vec2.T{1, 1}, 1, 1, 0, false, true, vec2.T{1, 1}); got != nil {
+		t.Errorf("identical endpoints: got %v, want nil", got)
+	}
+	if got := NewSVGArc(vec2.T{0, 0}, 0, 1, 0, false, true, vec2.T{1, 1}); !got.IsLine() {
+		t.Errorf("zero radius: got %T, want Line", got)
+	}
+	// Radii too small to span the endpoints are scaled up to a semicircle.
+	v := NewSVGArc(vec2.T{0, 0}, 0.1, 0.1, 0, false, true, vec2.T{2, 0}).(Arc)
+	if rx, ry := v.Radii(); math.Abs(rx-1) > 1e-12 || math.Abs(ry-1) > 1e-12 {
+		t.Errorf("Radii = %v, %v, want 1, 1", rx, ry)
+	}
+}
+
+func TestArcLength(t *testing.T) {
+	v := NewArc(vec2.T{0, 0}, 2, 2, 0, 0, 90)
+	if got, want := v.Length(), math.Pi; math.Abs(got-want) > 1e-15 {
+		t.Errorf("Length failed: got %v, want %v", got, want)
+	}
+	e := NewArc(vec2.T{0, 0}, 2, 1, 0, 0, 360)
+	// Ramanujan's approximation is accurate to ~1e-9 for this eccentricity.
+	h := (2.0 - 1) * (2.0 - 1) / ((2.0 + 1) * (2.0 + 1))
+	want := math.Pi * 3 * (1 + 3*h/(10+math.Sqrt(4-3*h)))
+	if got := e.Length(); math.Abs(got-want) > 1e-6 {
+		t.Errorf("ellipse Length failed: got %v, want %v", got, want)
+	}
+}
+
+func TestArcSubdivide(t *testing.T) {
+	tests := []struct {
+		name       string
+		arc        Arc
+		maxDegrees float64
+		want       int // number of slices
+	}{
+		{"half circle", NewArc(vec2.T{0, 0}, 1, 1, 0, 0, 180), 45, 4},
+		{"fine full circle", NewArc(vec2.T{0, 0}, 1, 1, 0, 0, 360), 1, 360},
+		{"clockwise quarter", NewArc(vec2.T{1, 2}, 3, 3, 0, 30, -90), 10, 9},
+		{"small arc", NewArc(vec2.T{0, 0}, 1, 1, 0, 0, 5), 10, 2},
+		{"full ellipse", NewArc(vec2.T{0, 0}, 4, 1, 30, 10, 360), 2, 180},
+		{"ellipse quarter", NewArc(vec2.T{0, 0}, 4, 1, 0, 0, 90), 1, 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := tt.arc.Subdivide(tt.maxDegrees)
+			if len(ts)-1 != tt.want {
+				t.Errorf("Subdivide = %v slices, want %v", len(ts)-1, tt.want)
+			}
+			if ts[0] != 0 || ts[len(ts)-1] != 1 {
+				t.Errorf("Subdivide = %v, want it to run from 0 to 1", ts)
+			}
+			for i := 0; i < len(ts)-1; i++ {
+				a, b := tt.arc.NTangent(ts[i]), tt.arc.NTangent(ts[i+1])
+				if ts[i+1] <= ts[i] {
+					t.Errorf("Subdivide: t=%v follows t=%v", ts[i+1], ts[i])
+				}
+				if angle := normalAngle(&a, &b) * 180 / math.Pi; angle > tt.maxDegrees+1e-9 {
+					t.Errorf("Subdivide: angle between t=%v and t=%v is %v degrees", ts[i], ts[i+1], angle)
+				}
+			}
+		})
+	}
+}
+
+func TestArcBevel_SmoothJoin(t *testing.T) {
+	// The normals of consecutive quarters of a circle match at their joins
+	// up to rounding, which must not be mistaken for a reversal.
+	c := circleSubPath(vec2.T{5, 5}, 5, false)
+	prevNN, nextNN := c.Segments[3].NNormal(1), c.Segments[1].NNormal(0)
+	prevNN.Invert()
+	nextNN.Invert()
+	tris, _, err := c.Segments[0].Bevel(0, 2, 45, 10, true, &prevNN, &nextNN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tri := range tris {
+		for _, v := range tri {
+			want := 5.0
+			if v[2] != 0 {
+				want = 3
+			}
+			if d := math.Hypot(v[0]-5, v[1]-5); math.Abs(d-want) > 1e-9 {
+				t.Fatalf("Bevel vertex %v is %v from the center, want %v", v, d, want)
+			}
+		}
+	}
+}
+
+func TestArcSplitAndSub(t *testing.T) {
+	v := NewArc(vec2.T{1, 1}, 3, 1, 30, 45, 200)
+	checkSplitAndSub(t, v)
+	sub := v.Sub(0.5, 0)
+	if got, want := sub.(Arc).SweepAngle(), -100.0; math.Abs(got-want) > 1e-12 {
+		t.Errorf("Sub(0.5, 0) sweep = %v, want %v", got, want)
+	}
+}
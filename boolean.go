@@ -0,0 +1,53 @@
+package parametric2d
+
+// Union returns a Path enclosing the regions that are solid in either
+// 'p' or 'q' (according to each Path's FillRule). `maxDegrees` determines
+// how finely curves are flattened to find where the Paths cross.
+//
+// The boundaries of the result are rebuilt from the parts of the original
+// segments (so curves remain curves) and wind counter-clockwise around
+// solids and clockwise around holes, with normals that face the solid.
+// Coincident edges are merged, or removed when they separate two solid
+// regions.
+func (p *Path) Union(q *Path, maxDegrees float64) *Path {
+	return p.boolean(q, maxDegrees, func(a, b bool) bool { return a || b })
+}
+
+// Intersection returns a Path enclosing the regions that are solid in
+// both 'p' and 'q'. See Union.
+func (p *Path) Intersection(q *Path, maxDegrees float64) *Path {
+	return p.boolean(q, maxDegrees, func(a, b bool) bool { return a && b })
+}
+
+// Difference returns a Path enclosing the regions that are solid in 'p'
+// but not in 'q'. See Union.
+func (p *Path) Difference(q *Path, maxDegrees float64) *Path {
+	return p.boolean(q, maxDegrees, func(a, b bool) bool { return a && !b })
+}
+
+// Xor returns a Path enclosing the regions that are solid in exactly one
+// of 'p' and 'q'. See Union.
+func (p *Path) Xor(q *Path, maxDegrees float64) *Path {
+	return p.boolean(q, maxDegrees, func(a, b bool) bool { return a != b })
+}
+
+// boolean combines the solid regions of two Paths using 'op'.
+func (p *Path) boolean(q *Path, maxDegrees float64, op func(a, b bool) bool) *Path {
+	contours := append(flattenContours(p.SubPaths, 0, maxDegrees), flattenContours(q.SubPaths, 1, maxDegrees)...)
+	ar := newArrangement(contours)
+	loops := ar.boundary(func(w [2]int) bool { return op(p.FillRule.inside(w[0]), q.FillRule.inside(w[1])) })
+	subPaths, reversed, _ := ar.subPaths(loops, contours)
+	r := &Path{SubPaths: make([]*SubPath, len(subPaths))}
+	for i, sp := range subPaths {
+		// Copy the SubPaths so the result is independent of its inputs, and
+		// reverse unchanged SubPaths that run against their loops (an outer
+		// SubPath of 'q' becomes a hole in a Difference, for example).
+		cp := &SubPath{Segments: append([]T(nil), sp.Segments...)}
+		if reversed[i] {
+			cp.Reverse()
+			cp.FlipNormals = false
+		}
+		r.SubPaths[i] = cp
+	}
+	return r
+}
@@ -0,0 +1,154 @@
+package parametric2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// netArea returns the sum of the signed areas of the Path's SubPaths.
+func netArea(p *Path) float64 {
+	var area float64
+	for _, sp := range p.SubPaths {
+		area += sp.SignedArea()
+	}
+	return area
+}
+
+func TestPathBoolean(t *testing.T) {
+	tests := []struct {
+		name                                 string
+		a, b                                 string
+		union, intersection, difference, xor float64
+	}{
+		{
+			name:  "overlapping squares",
+			a:     "M0 0h2v2h-2z",
+			b:     "M1 1h2v2h-2z",
+			union: 7, intersection: 1, difference: 3, xor: 6,
+		},
+		{
+			name:  "shared edge",
+			a:     "M0 0h2v2h-2z",
+			b:     "M2 0h2v2h-2z",
+			union: 8, intersection: 0, difference: 4, xor: 8,
+		},
+		{
+			name:  "identical squares",
+			a:     "M0 0h2v2h-2z",
+			b:     "M0 2v-2h2v2z",
+			union: 4, intersection: 4, difference: 0, xor: 0,
+		},
+		{
+			name:  "disjoint squares",
+			a:     "M0 0h2v2h-2z",
+			b:     "M5 5h2v2h-2z",
+			union: 8, intersection: 0, difference: 4, xor: 8,
+		},
+		{
+			name:  "island in hole",
+			a:     "M0 0h10v10h-10z M2 2h6v6h-6z",
+			b:     "M4 4h2v2h-2z",
+			union: 68, intersection: 0, difference: 64, xor: 68,
+		},
+		{
+			name:  "square across hole",
+			a:     "M0 0h10v10h-10z M2 2h6v6h-6z",
+			b:     "M1 4h8v2h-8z",
+			union: 64 + 12, intersection: 4, difference: 60, xor: 72,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := mustParseSVGPath(t, tt.a), mustParseSVGPath(t, tt.b)
+			for _, op := range []struct {
+				name string
+				got  *Path
+				want float64
+			}{
+				{"Union", a.Union(b, 10), tt.union},
+				{"Intersection", a.Intersection(b, 10), tt.intersection},
+				{"Difference", a.Difference(b, 10), tt.difference},
+				{"Xor", a.Xor(b, 10), tt.xor},
+			} {
+				if got := netArea(op.got); math.Abs(got-op.want) > 1e-9 {
+					t.Errorf("%v area = %v, want %v", op.name, got, op.want)
+				}
+				if op.want == 0 {
+					continue
+				}
+				tris, err := op.got.Wall(1, 10)
+				if err != nil {
+					t.Fatalf("%v Wall: %v", op.name, err)
+				}
+				if got := projectedArea(tris); math.Abs(got-op.want) > 1e-9 {
+					t.Errorf("%v floor area = %v, want %v", op.name, got, op.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPathBoolean_Curves(t *testing.T) {
+	// Two circles of radius 2 whose centers are 2 apart.
+	a := &Path{SubPaths: []*SubPath{circleSubPath(vec2.T{0, 0}, 2, true)}}
+	b := &Path{SubPaths: []*SubPath{circleSubPath(vec2.T{2, 0}, 2, true)}}
+	lens := 8*math.Pi/3 - 2*math.Sqrt(3)
+	tests := []struct {
+		name string
+		got  *Path
+		want float64
+	}{
+		{"Union", a.Union(b, 10), 8*math.Pi - lens},
+		{"Intersection", a.Intersection(b, 10), lens},
+		{"Difference", a.Difference(b, 10), 4*math.Pi - lens},
+	}
+	for _, tt := range tests {
+		if len(tt.got.SubPaths) != 1 {
+			t.Fatalf("%v = %v subpaths, want 1", tt.name, len(tt.got.SubPaths))
+		}
+		sp := tt.got.SubPaths[0]
+		for i, seg := range sp.Segments {
+			if _, ok := seg.(Arc); !ok {
+				t.Errorf("%v segment #%v = %T, want Arc", tt.name, i, seg)
+			}
+			next := sp.Segments[(i+1)%len(sp.Segments)]
+			if p0, p1 := seg.At(1), next.At(0); !vecNear(p0, p1, 1e-9) {
+				t.Errorf("%v segment #%v ends at %v but the next starts at %v", tt.name, i, p0, p1)
+			}
+		}
+		if got := sp.SignedArea(); math.Abs(got-tt.want) > 0.05 {
+			t.Errorf("%v area = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPathDifference_Cutout(t *testing.T) {
+	plate := mustParseSVGPath(t, "M0 0h10v10h-10z")
+	center := vec2.T{5, 5}
+	cutout := &Path{SubPaths: []*SubPath{circleSubPath(center, 2, true)}}
+	got := plate.Difference(cutout, 10)
+	if len(got.SubPaths) != 2 {
+		t.Fatalf("Difference = %v subpaths, want 2", len(got.SubPaths))
+	}
+	for _, sp := range got.SubPaths {
+		if _, ok := sp.Segments[0].(Arc); !ok {
+			continue
+		}
+		// The normals of the hole must face away from its center (into the plate).
+		if normalsPointInward(sp, center) {
+			t.Errorf("normals of the cutout face into the hole")
+		}
+		if sp == cutout.SubPaths[0] {
+			t.Errorf("Difference shares a SubPath with its input")
+		}
+	}
+	tris, err := got.Bevel(1, 0.5, 45, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tris) == 0 {
+		t.Errorf("Bevel returned no triangles")
+	}
+}
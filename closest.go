@@ -0,0 +1,138 @@
+package parametric2d
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// bezierPoly returns the power basis coefficients (lowest order first)
+// along the given axis of the Bezier curve with control points 'pts'.
+func bezierPoly(pts []vec2.T, axis int) []float64 {
+	n := len(pts) - 1
+	c := make([]float64, n+1)
+	for j := 0; j <= n; j++ {
+		var sum float64
+		for i := 0; i <= j; i++ {
+			term := binomial(j, i) * pts[i][axis]
+			if (i+j)%2 != 0 {
+				term = -term
+			}
+			sum += term
+		}
+		c[j] = binomial(n, j) * sum
+	}
+	return c
+}
+
+func binomial(n, k int) float64 {
+	r := 1.0
+	for i := 1; i <= k; i++ {
+		r = r * float64(n-k+i) / float64(i)
+	}
+	return r
+}
+
+// closestPointOnBezier returns the position along the Bezier curve 'seg'
+// (with control points 'pts') that is closest to p, and its distance.
+//
+// The squared distance is minimized where (B(t) - p) . B'(t) = 0, which
+// for a cubic is a quintic polynomial whose roots are found directly.
+func closestPointOnBezier(seg T, pts []vec2.T, p vec2.T) (float64, float64) {
+	var f []float64
+	for axis := 0; axis < 2; axis++ {
+		b := bezierPoly(pts, axis)
+		b[0] -= p[axis]
+		g := polyMul(b, polyDeriv(b))
+		if f == nil {
+			f = g
+			continue
+		}
+		for i := range g {
+			f[i] += g[i]
+		}
+	}
+	return closestOf(seg, p, append([]float64{0, 1}, polyRoots(f, 0, 1)...))
+}
+
+// closestOf returns the candidate position along the segment that is
+// closest to p, and its distance.
+func closestOf(seg T, p vec2.T, ts []float64) (float64, float64) {
+	bestT, best := 0.0, math.Inf(1)
+	for _, t := range ts {
+		q := seg.At(t)
+		if d := distance(p, q); d < best {
+			bestT, best = t, d
+		}
+	}
+	return bestT, best
+}
+
+// closestSamples is the number of samples used to bracket the closest
+// point on segments without a polynomial form.
+const closestSamples = 64
+
+// closestPointSampled returns the position along the segment closest to
+// p by sampling it and refining each local minimum with a golden-section
+// search.
+func closestPointSampled(seg T, p vec2.T) (float64, float64) {
+	dist := func(t float64) float64 {
+		return distance(p, seg.At(t))
+	}
+	ds := make([]float64, closestSamples+1)
+	for i := range ds {
+		ds[i] = dist(float64(i) / closestSamples)
+	}
+	ts := []float64{0, 1}
+	for i := 0; i <= closestSamples; i++ {
+		// A minimum at an end sample may still lie just inside the end.
+		if (i > 0 && ds[i] > ds[i-1]) || (i < closestSamples && ds[i] > ds[i+1]) {
+			continue
+		}
+		a := math.Max(float64(i-1)/closestSamples, 0)
+		b := math.Min(float64(i+1)/closestSamples, 1)
+		const g = 0.6180339887498949 // (sqrt(5) - 1) / 2
+		c, d := b-g*(b-a), a+g*(b-a)
+		fc, fd := dist(c), dist(d)
+		for b-a > 1e-12 {
+			if fc < fd {
+				b, d, fd = d, c, fc
+				c = b - g*(b-a)
+				fc = dist(c)
+			} else {
+				a, c, fc = c, d, fd
+				d = a + g*(b-a)
+				fd = dist(d)
+			}
+		}
+		ts = append(ts, 0.5*(a+b))
+	}
+	return closestOf(seg, p, ts)
+}
+
+// byBBoxDistance returns the indices of the rectangles ordered by their
+// distance from p.
+func byBBoxDistance(bboxes []vec2.Rect, p vec2.T) []int {
+	order := make([]int, len(bboxes))
+	dists := make([]float64, len(bboxes))
+	for i, r := range bboxes {
+		order[i] = i
+		dists[i] = bboxDistance(r, p)
+	}
+	sort.Slice(order, func(i, j int) bool { return dists[order[i]] < dists[order[j]] })
+	return order
+}
+
+// distance returns the distance between two points.
+func distance(a, b vec2.T) float64 {
+	return math.Hypot(a[0]-b[0], a[1]-b[1])
+}
+
+// bboxDistance returns the distance from p to the rectangle, which is
+// zero if p lies within it.
+func bboxDistance(r vec2.Rect, p vec2.T) float64 {
+	dx := math.Max(0, math.Max(r.Min[0]-p[0], p[0]-r.Max[0]))
+	dy := math.Max(0, math.Max(r.Min[1]-p[1], p[1]-r.Max[1]))
+	return math.Hypot(dx, dy)
+}
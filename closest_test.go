@@ -0,0 +1,97 @@
+package parametric2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+func TestPolyRoots(t *testing.T) {
+	tests := []struct {
+		name string
+		c    []float64
+		want []float64
+	}{
+		{"constant", []float64{1}, nil},
+		{"linear", []float64{-0.5, 1}, []float64{0.5}},
+		// (t-0.1)(t-0.5)(t-0.9)
+		{"cubic", []float64{-0.045, 0.59, -1.5, 1}, []float64{0.1, 0.5, 0.9}},
+		{"no real roots", []float64{1, 0, 1}, nil},
+		// (t-0.25)(t+3)(t-2) has a single root in [0, 1].
+		{"roots outside", []float64{1.5, -6.25, 0.75, 1}, []float64{0.25}},
+		{"root at end", []float64{-1, 1}, []float64{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := polyRoots(tt.c, 0, 1)
+			if len(got) != len(tt.want) {
+				t.Fatalf("polyRoots = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-12 {
+					t.Errorf("polyRoots = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestClosestPoint(t *testing.T) {
+	segs := map[string]T{
+		"line":      NewLine(vec2.T{1, 2}, vec2.T{4, -1}),
+		"curve":     NewCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, -2}, vec2.T{3, 0}),
+		"loop":      NewCurve(vec2.T{0, 0}, vec2.T{4, 3}, vec2.T{-2, 3}, vec2.T{2, 0}),
+		"quadcurve": NewQuadCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0}),
+		"circle":    NewArc(vec2.T{1, 1}, 2, 2, 30, 10, 250),
+		"ellipse":   NewArc(vec2.T{-1, 2}, 3, 1, 40, -30, -200),
+		// The nearest point to (2.63, 0.961) lies before the first sample.
+		"ellipse start": NewArc(vec2.T{0, 0}, 3, 1, 20, 0, 90),
+	}
+	points := []vec2.T{{0, 0}, {1, 1}, {2, 0.5}, {-3, 4}, {5, -5}, {1, 1.2}, {0.5, 1.5}, {2.63, 0.961}}
+	for name, seg := range segs {
+		t.Run(name, func(t *testing.T) {
+			for _, p := range points {
+				gotT, gotD := seg.ClosestPoint(p)
+				if d := distance(p, seg.At(gotT)); math.Abs(d-gotD) > 1e-12 {
+					t.Errorf("ClosestPoint(%v) distance = %v, but At(%v) is %v away", p, gotD, gotT, d)
+				}
+				// Compare against brute force sampling.
+				want := math.Inf(1)
+				for i := 0; i <= 100000; i++ {
+					want = math.Min(want, distance(p, seg.At(float64(i)/100000)))
+				}
+				if gotD > want+1e-9 || gotD < want-1e-6 {
+					t.Errorf("ClosestPoint(%v) = (t=%v, %v), want distance %v", p, gotT, gotD, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPathClosestPoint(t *testing.T) {
+	p := mustParseSVGPath(t, "M0 0h10v10h-10z M20 0a5 5 0 1 0 10 0a5 5 0 1 0 -10 0z")
+	tests := []struct {
+		pt       vec2.T
+		wantSP   int
+		wantSeg  int
+		wantT    float64
+		wantDist float64
+	}{
+		{vec2.T{5, -2}, 0, 0, 0.5, 2},
+		{vec2.T{12, 5}, 0, 1, 0.5, 2},
+		{vec2.T{25, 0}, 1, 0, 0, 5},
+		{vec2.T{25, 7}, 1, 0, 0.5, 2},
+		{vec2.T{25, -6}, 1, 1, 0.5, 1},
+	}
+	for _, tt := range tests {
+		sp, seg, tt0, d := p.ClosestPoint(tt.pt)
+		if sp != tt.wantSP || seg != tt.wantSeg || math.Abs(tt0-tt.wantT) > 1e-9 || math.Abs(d-tt.wantDist) > 1e-9 {
+			t.Errorf("ClosestPoint(%v) = (%v, %v, %v, %v), want (%v, %v, %v, %v)",
+				tt.pt, sp, seg, tt0, d, tt.wantSP, tt.wantSeg, tt.wantT, tt.wantDist)
+		}
+	}
+	if sp, seg, _, d := (&Path{}).ClosestPoint(vec2.T{}); sp != -1 || seg != -1 || !math.IsInf(d, 1) {
+		t.Errorf("empty Path ClosestPoint = (%v, %v, %v), want (-1, -1, +Inf)", sp, seg, d)
+	}
+}
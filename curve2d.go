@@ -1,13 +1,9 @@
 package parametric2d
 
 import (
-	"fmt"
-	"math"
-
 	"github.com/gmlewis/go-poly2tri"
 	"github.com/gmlewis/go3d/float64/bezier2"
 	"github.com/gmlewis/go3d/float64/vec2"
-	"github.com/gmlewis/go3d/float64/vec3"
 )
 
 // Curve represents a 2D Bezier curve and implements interface T.
@@ -16,27 +12,33 @@ type Curve struct {
 	bbox   vec2.Rect
 }
 
-// NewCurve returns a new 2D Bezier curve from four points.
+// NewCurve returns a new 2D Bezier curve from four points. A control point
+// may coincide with its endpoint, as it does for an SVG "S" command that
+// does not follow another curve; see NTangent.
 func NewCurve(p0, p1, p2, p3 vec2.T) Curve {
-	ll := vec2.Min(&p0, &p3)
-	ur := vec2.Max(&p0, &p3)
-	// Evaluate the bezier spline at t=0.25, 0.5, 0.75
-	// to get accurate bounds for the curve.
 	s := bezier2.T{P0: p0, P1: p1, P2: p2, P3: p3}
-	if p0 == p1 {
-		fmt.Printf("Warning! cubic bezier p0==p1==%v.  Setting p1=p2=%v\n", p0, p2)
-		s.P1 = p2
-	}
-	if p2 == p3 {
-		fmt.Printf("Warning! cubic bezier p2==p3==%v.  Setting p2=p1=%v\n", p3, p1)
-		s.P2 = p1
-	}
-	for _, t := range []float64{0.25, 0.5, 0.75} {
-		v := s.Point(t)
-		ll = vec2.Min(&ll, &v)
-		ur = vec2.Max(&ur, &v)
+	return Curve{spline: s, bbox: cubicBBox(&s)}
+}
+
+// cubicBBox returns the tight bounds of a cubic Bezier spline by
+// evaluating it at its endpoints and at the roots of its derivative
+// along each axis.
+func cubicBBox(s *bezier2.T) vec2.Rect {
+	ll := vec2.Min(&s.P0, &s.P3)
+	ur := vec2.Max(&s.P0, &s.P3)
+	for axis := 0; axis < 2; axis++ {
+		p0, p1, p2, p3 := s.P0[axis], s.P1[axis], s.P2[axis], s.P3[axis]
+		// The derivative (divided by 3) is a*t^2 + b*t + c.
+		a := -p0 + 3*p1 - 3*p2 + p3
+		b := 2 * (p0 - 2*p1 + p2)
+		c := p1 - p0
+		for _, t := range rootsInUnitInterval(quadraticRoots(a, b, c)) {
+			v := s.Point(t)
+			ll = vec2.Min(&ll, &v)
+			ur = vec2.Max(&ur, &v)
+		}
 	}
-	return Curve{spline: s, bbox: vec2.Rect{Min: ll, Max: ur}}
+	return vec2.Rect{Min: ll, Max: ur}
 }
 
 // BBox returns the minimum bounding box of the Curve.
@@ -52,16 +54,54 @@ func (s Curve) At(t float64) vec2.T {
 // Tangent returns the tangent to the Curve
 // at the given position (0 <= t <= 1).
 func (s Curve) Tangent(t float64) vec2.T {
-	return s.spline.Tangent(t)
+	// bezier2.Tangent panics on a zero tangent (e.g. at a cusp), so the
+	// derivative is evaluated here and a zero tangent is returned instead.
+	p := &s.spline
+	t1 := 1 - t
+	a := vec2.Sub(&p.P1, &p.P0)
+	b := vec2.Sub(&p.P2, &p.P1)
+	c := vec2.Sub(&p.P3, &p.P2)
+	return vec2.T{
+		3*t1*t1*a[0] + 6*t1*t*b[0] + 3*t*t*c[0],
+		3*t1*t1*a[1] + 6*t1*t*b[1] + 3*t*t*c[1],
+	}
 }
 
 // NTangent returns the normalized tangent to the Curve
-// at the given position (0 <= t <= 1).
+// at the given position (0 <= t <= 1). If a control point coincides with
+// the end of the curve at t=0 or t=1, the tangent vanishes there, and the
+// direction in which the curve leaves or arrives at the end is returned
+// instead.
 func (s Curve) NTangent(t float64) vec2.T {
-	v := s.Tangent(t)
+	v := s.direction(t)
 	return *v.Normalize()
 }
 
+// direction returns the tangent at 't', or at an end of the curve where
+// the tangent vanishes, the direction from the end toward the first
+// control point that does not coincide with it (reversed at t=1).
+func (s Curve) direction(t float64) vec2.T {
+	v := s.Tangent(t)
+	if !v.IsZero() || (t != 0 && t != 1) {
+		return v
+	}
+	p := &s.spline
+	pts := []vec2.T{p.P0, p.P1, p.P2, p.P3}
+	if t == 1 {
+		pts = []vec2.T{p.P3, p.P2, p.P1, p.P0}
+	}
+	for _, q := range pts[1:] {
+		if q != pts[0] {
+			d := vec2.Sub(&q, &pts[0])
+			if t == 1 {
+				d.Invert()
+			}
+			return d
+		}
+	}
+	return v
+}
+
 // Normal returns the normal to the Curve
 // at the given position (0 <= t <= 1).
 func (s Curve) Normal(t float64) vec2.T {
@@ -70,192 +110,29 @@ func (s Curve) Normal(t float64) vec2.T {
 }
 
 // NNormal returns the normalized normal to the Curve
-// at the given position (0 <= t <= 1).
+// at the given position (0 <= t <= 1), which is defined at its ends as
+// for NTangent.
 func (s Curve) NNormal(t float64) vec2.T {
-	v := s.Normal(t)
+	v := s.direction(t)
+	v.Rotate90DegLeft()
 	return *v.Normalize()
 }
 
 // Subdivide returns the parametric 't' values along the curve
 // such that the tangent between two points never exceeds `maxDegrees`.
 func (s Curve) Subdivide(maxDegrees float64) []float64 {
-	// Start with 3 points, and subdivide as necessary:
-	ts := []float64{0, 0.5, 1}
-	tangents := []vec2.T{s.NTangent(0), s.NTangent(0.5), s.NTangent(1)}
-	maxRadians := math.Abs(maxDegrees * math.Pi / 180.0)
-	i := 0
-	for i < len(ts)-1 {
-		if ts[i+1]-ts[i] < 1e-2 {
-			fmt.Printf("Stopping subdivision: ts[%v]=%v, ts[%v]=%v\n", i+1, ts[i+1], i, ts[i])
-			i++
-			continue
-		}
-		angle := math.Abs(vec2.Angle(&tangents[i], &tangents[i+1]))
-		if angle > maxRadians { // Subdivide
-			m := 0.5 * (ts[i] + ts[i+1])
-			tan := s.NTangent(m)
-			// Insert the new t and tangent into the slice.
-			ts = append(ts, 0) // make room
-			copy(ts[i+2:], ts[i+1:])
-			ts[i+1] = m
-			tangents = append(tangents, vec2.T{0, 0}) // make room
-			copy(tangents[i+2:], tangents[i+1:])
-			tangents[i+1] = tan
-		} else {
-			i++
-		}
-	}
-	return ts
+	return subdivide(s, maxDegrees)
 }
 
 // Wall extrudes a curve into a 3D wall. `maxDegrees` determines the smoothness
 // of the wall along the curve.
-func (s Curve) Wall(height, maxDegrees float64, flipNormals bool) ([]Triangle3D, poly2tri.PointArray) {
-	ts := s.Subdivide(maxDegrees)
-	num := len(ts)
-	if num <= 0 {
-		return []Triangle3D{}, poly2tri.PointArray{}
-	}
-	v := make([]Triangle3D, 0, 2*num)
-	floorPts := make(poly2tri.PointArray, 0, num-1)
-	for i := 0; i < num-1; i++ {
-		p0 := s.At(ts[i])
-		p1 := s.At(ts[i+1])
-		t := Triangle3D{
-			vec3.T{p0[0], p0[1], 0},
-			vec3.T{p1[0], p1[1], height},
-			vec3.T{p0[0], p0[1], height},
-		}
-		if flipNormals {
-			t[1], t[2] = t[2], t[1]
-		}
-		v = append(v, t)
-		// if i == 0 {
-		// 	floorPts = append(floorPts, poly2tri.NewPoint(p0[0], p0[1]))
-		// }
-		t = Triangle3D{
-			vec3.T{p0[0], p0[1], 0},
-			vec3.T{p1[0], p1[1], 0},
-			vec3.T{p1[0], p1[1], height},
-		}
-		if flipNormals {
-			t[1], t[2] = t[2], t[1]
-		}
-		v = append(v, t)
-		floorPts = append(floorPts, poly2tri.NewPoint(p1[0], p1[1]))
-	}
-	return v, floorPts
+func (s Curve) Wall(height, maxDegrees float64, flipNormals bool) ([]Triangle3D, poly2tri.PointArray, error) {
+	return wall(s, s.Subdivide(maxDegrees), height, flipNormals)
 }
 
 // Bevel returns a 3D beveled object based on the provided curve.
-func (s Curve) Bevel(height, offset, deg, maxDegrees float64, flipNormals bool, prevNN, nextNN *vec2.T) ([]Triangle3D, poly2tri.PointArray) {
-	ts := s.Subdivide(maxDegrees)
-	num := len(ts)
-	if num <= 0 {
-		return []Triangle3D{}, poly2tri.PointArray{}
-	}
-	h := offset * math.Tan(deg*math.Pi/180.0)
-	v := make([]Triangle3D, 0, 2*num)
-	bevelPts := make(poly2tri.PointArray, 0, num-1)
-	for i := 0; i < num-1; i++ {
-		p0 := s.At(ts[i])
-		p1 := s.At(ts[i+1])
-		n0 := s.NNormal(ts[i])
-		n1 := s.NNormal(ts[i+1])
-		n0f := offset
-		n1f := offset
-		if flipNormals {
-			n0[0], n0[1], n1[0], n1[1] = -n0[0], -n0[1], -n1[0], -n1[1]
-		}
-		if i == 0 {
-			angle0 := vec2.Angle(prevNN, &n0)
-			if *prevNN != n0 {
-				// Adjusting starting triangle n0f=1.0000532533019526, prevNN=[0.3559858534155444 -0.9344913440840459], n0=[0.37519651438861046 -0.9269452926632926], angle0=0.020639949379423816
-				// Created regular start-of-curve triangle:
-				// [[181.08017999999998 -499.24048 4] [182.489264893852 -499.72347812262666 5] [181.44581012281427 -500.17129744866037 5]]
-				n0f = offset / math.Cos(0.5*angle0)
-				fmt.Printf("Adjusting starting triangle n0f=%v, prevNN=%v, n0=%v, angle0=%v\n", n0f, *prevNN, n0, angle0)
-				n0.Add(prevNN)
-				n0.Normalize()
-			} else {
-				fmt.Printf("Warning: prevNN==n0==%v, i=%v, p0=%v\n", n0, i, p0)
-			}
-		}
-		if i+1 == num-1 {
-			angle1 := vec2.Angle(&n1, nextNN)
-			if n1 != *nextNN {
-				n1f = offset / math.Cos(0.5*angle1)
-				// fmt.Printf("n1f=%v, n1=%v, nextNN=%v, angle1=%v\n", n1f, n1, *nextNN, angle1)
-				n1.Add(nextNN)
-				n1.Normalize()
-			} else {
-				fmt.Printf("Warning: n1==nextNN==%v, i=%v, p1=%v\n", n1, i, p1)
-			}
-		}
-		p2 := n0.Scale(n0f).Add(&p0)
-		p3 := n1.Scale(n1f).Add(&p1)
-		if segmentsIntersect(&p0, p2, &p1, p3) { // p0-p2 intersects p1-p3 - delete p3 and add new triangle
-			fmt.Printf("Detected intersection: i=%v, num=%v\n", i, num)
-			if i == 0 {
-				// Do not add a bevelPts for this case because p2 has already been accounted for by last segment
-				t := Triangle3D{
-					vec3.T{p0[0], p0[1], height},
-					vec3.T{p1[0], p1[1], height},
-					vec3.T{p2[0], p2[1], height + h},
-				}
-				fmt.Printf("Created start-of-curve triangle: %v\n", t)
-				if flipNormals {
-					t[1], t[2] = t[2], t[1]
-				}
-				v = append(v, t)
-			} else if i+1 == num-1 { // End of the curve - need to add to the bevelPts
-				t := Triangle3D{
-					vec3.T{p0[0], p0[1], height},
-					vec3.T{p1[0], p1[1], height},
-					vec3.T{p3[0], p3[1], height + h},
-				}
-				fmt.Printf("Created end-of-curve triangle: %v\n", t)
-				// Created end-of-curve triangle:
-				// Intersection: [182.07626125,-498.81274874999997]-[182.489264893852,-499.72347812262666]
-				//             X [183.09580999999997,-498.32642]-[182.09580999999997,-499.9444329390584]
-				// [[182.07626125 -498.81274874999997 4]
-				// [183.09580999999997 -498.32642 4]
-				// [182.09580999999997 -499.9444329390584 5]]
-				if flipNormals {
-					t[1], t[2] = t[2], t[1]
-				}
-				v = append(v, t)
-				bevelPts = append(bevelPts, poly2tri.NewPoint(p3[0], p3[1]))
-			} else { // adjust to previous point.
-				panic("curve2d.go: unhandled problem")
-			}
-		} else {
-			t := Triangle3D{
-				vec3.T{p0[0], p0[1], height},
-				vec3.T{p3[0], p3[1], height + h},
-				vec3.T{p2[0], p2[1], height + h},
-			}
-			if i == 0 {
-				fmt.Printf("Created regular start-of-curve triangle:\n%v\n", t)
-			}
-			if flipNormals {
-				t[1], t[2] = t[2], t[1]
-			}
-			v = append(v, t)
-			t = Triangle3D{
-				vec3.T{p0[0], p0[1], height},
-				vec3.T{p1[0], p1[1], height},
-				vec3.T{p3[0], p3[1], height + h},
-			}
-			if flipNormals {
-				t[1], t[2] = t[2], t[1]
-			}
-			v = append(v, t)
-			bevelPts = append(bevelPts, poly2tri.NewPoint(p3[0], p3[1]))
-		}
-	}
-	fmt.Printf("Bevel: #subdivisions=%v: #v=%v, #bevelPts=%v\n", num, len(v), len(bevelPts))
-	return v, bevelPts
+func (s Curve) Bevel(height, offset, deg, maxDegrees float64, flipNormals bool, prevNN, nextNN *vec2.T) ([]Triangle3D, poly2tri.PointArray, error) {
+	return bevel(s, s.Subdivide(maxDegrees), height, offset, deg, flipNormals, prevNN, nextNN)
 }
 
 // IsLine is false for type Curve.
@@ -263,49 +140,66 @@ func (s Curve) IsLine() bool {
 	return false
 }
 
-func segmentsIntersect(a, b, c, d *vec2.T) bool {
-	div := (a[0]-b[0])*(c[1]-d[1]) - (a[1]-b[1])*(c[0]-d[0])
-	if div == 0 {
-		return false
-	}
-	x := ((a[0]*b[1]-a[1]*b[0])*(c[0]-d[0]) - (a[0]-b[0])*(c[0]*d[1]-c[1]*d[0])) / div
-	y := ((a[0]*b[1]-a[1]*b[0])*(c[1]-d[1]) - (a[1]-b[1])*(c[0]*d[1]-c[1]*d[0])) / div
-	if a[0] >= b[0] {
-		if !(b[0] <= x && x <= a[0]) {
-			return false
-		}
-	} else {
-		if !(a[0] <= x && x <= b[0]) {
-			return false
-		}
-	}
-	if a[1] >= b[1] {
-		if !(b[1] <= y && y <= a[1]) {
-			return false
-		}
-	} else {
-		if !(a[1] <= y && y <= b[1]) {
-			return false
-		}
-	}
-	if c[0] >= d[0] {
-		if !(d[0] <= x && x <= c[0]) {
-			return false
-		}
-	} else {
-		if !(c[0] <= x && x <= d[0]) {
-			return false
-		}
+// Reverse returns the Curve traversed from its last point to its first.
+func (s Curve) Reverse() T {
+	sp := bezier2.T{P0: s.spline.P3, P1: s.spline.P2, P2: s.spline.P1, P3: s.spline.P0}
+	return Curve{spline: sp, bbox: s.bbox}
+}
+
+// Points returns the four control points of the Curve.
+func (s Curve) Points() (p0, p1, p2, p3 vec2.T) {
+	return s.spline.P0, s.spline.P1, s.spline.P2, s.spline.P3
+}
+
+// Split divides the Curve at position 't' using de Casteljau's algorithm.
+func (s Curve) Split(t float64) (T, T) {
+	p := &s.spline
+	p01 := vec2.Interpolate(&p.P0, &p.P1, t)
+	p12 := vec2.Interpolate(&p.P1, &p.P2, t)
+	p23 := vec2.Interpolate(&p.P2, &p.P3, t)
+	p012 := vec2.Interpolate(&p01, &p12, t)
+	p123 := vec2.Interpolate(&p12, &p23, t)
+	m := vec2.Interpolate(&p012, &p123, t)
+	a := bezier2.T{P0: p.P0, P1: p01, P2: p012, P3: m}
+	b := bezier2.T{P0: m, P1: p123, P2: p23, P3: p.P3}
+	// The control points are not adjusted as in NewCurve so that the
+	// pieces trace exactly the same path as the original.
+	return Curve{spline: a, bbox: cubicBBox(&a)}, Curve{spline: b, bbox: cubicBBox(&b)}
+}
+
+// Sub returns the part of the Curve between positions 't0' and 't1'.
+func (s Curve) Sub(t0, t1 float64) T {
+	if t1 < t0 {
+		return s.Sub(t1, t0).Reverse()
 	}
-	if c[1] >= d[1] {
-		if !(d[1] <= y && y <= c[1]) {
-			return false
-		}
-	} else {
-		if !(c[1] <= y && y <= d[1]) {
-			return false
-		}
+	_, tail := s.Split(t0)
+	if t0 >= 1 {
+		return tail
 	}
-	fmt.Printf("\nIntersection: [%v,%v]-[%v,%v] X [%v,%v]-[%v,%v]\n", a[0], a[1], b[0], b[1], c[0], c[1], d[0], d[1])
-	return true
+	head, _ := tail.Split((t1 - t0) / (1 - t0))
+	return head
+}
+
+// Transform returns the Curve transformed by 'm'. Since Bezier curves are
+// affine invariant, only the control points need to be transformed.
+func (s Curve) Transform(m Affine) T {
+	sp := bezier2.T{P0: m.Apply(s.spline.P0), P1: m.Apply(s.spline.P1), P2: m.Apply(s.spline.P2), P3: m.Apply(s.spline.P3)}
+	return Curve{spline: sp, bbox: cubicBBox(&sp)}
+}
+
+// ClosestPoint returns the position along the Curve nearest to 'p' and
+// its distance from 'p'.
+func (s Curve) ClosestPoint(p vec2.T) (float64, float64) {
+	return closestPointOnBezier(s, []vec2.T{s.spline.P0, s.spline.P1, s.spline.P2, s.spline.P3}, p)
+}
+
+// Length returns the arc length of the Curve.
+func (s Curve) Length() float64 {
+	return arcLength(s, 0, 1, DefaultLengthTolerance)
+}
+
+// AtLength returns the position (0 <= t <= 1) at the given arc length
+// along the Curve.
+func (s Curve) AtLength(l float64) float64 {
+	return tAtLength(s, s.Length(), l, DefaultLengthTolerance)
 }
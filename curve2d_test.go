@@ -1,6 +1,7 @@
 package parametric2d
 
 import (
+	"errors"
 	"math"
 	"testing"
 
@@ -26,6 +27,39 @@ func TestCurveBBox(t *testing.T) {
 	}
 }
 
+func TestCurveBBox_SCurve(t *testing.T) {
+	// y(t) = 6t(1-t)(1-2t) has extrema of +/-1/sqrt(3) at t=(3-/+sqrt(3))/6,
+	// which fall between the t=0.25, 0.5, 0.75 sample points.
+	v := NewCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, -2}, vec2.T{3, 0})
+	want := vec2.Rect{Min: vec2.T{0, -1 / math.Sqrt(3)}, Max: vec2.T{3, 1 / math.Sqrt(3)}}
+	got := v.BBox()
+	e := 1e-12
+	if math.Abs(got.Min[0]-want.Min[0]) > e || math.Abs(got.Min[1]-want.Min[1]) > e {
+		t.Errorf("BBox Min failed: got %v, want %v", got.Min, want.Min)
+	}
+	if math.Abs(got.Max[0]-want.Max[0]) > e || math.Abs(got.Max[1]-want.Max[1]) > e {
+		t.Errorf("BBox Max failed: got %v, want %v", got.Max, want.Max)
+	}
+}
+
+func TestCurveBBox_Loop(t *testing.T) {
+	v := NewCurve(vec2.T{0, 0}, vec2.T{4, 3}, vec2.T{-2, 3}, vec2.T{2, 0})
+	got := v.BBox()
+	want := vec2.Rect{Min: v.At(0), Max: v.At(0)}
+	for i := 0; i <= 10000; i++ {
+		p := v.At(float64(i) / 10000)
+		want.Min = vec2.Min(&want.Min, &p)
+		want.Max = vec2.Max(&want.Max, &p)
+	}
+	e := 1e-6
+	if math.Abs(got.Min[0]-want.Min[0]) > e || math.Abs(got.Min[1]-want.Min[1]) > e {
+		t.Errorf("BBox Min failed: got %v, want %v", got.Min, want.Min)
+	}
+	if math.Abs(got.Max[0]-want.Max[0]) > e || math.Abs(got.Max[1]-want.Max[1]) > e {
+		t.Errorf("BBox Max failed: got %v, want %v", got.Max, want.Max)
+	}
+}
+
 func TestCurveAt(t *testing.T) {
 	v := NewCurve(vec2.T{0, 0}, vec2.T{0, 1}, vec2.T{2, 1}, vec2.T{2, 0})
 	want := vec2.T{1, 0.75}
@@ -73,6 +107,26 @@ func TestCurveNNormal(t *testing.T) {
 	}
 }
 
+func TestCurveNNormal_CoincidentControlPoints(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Curve
+		t    float64
+		want vec2.T
+	}{
+		{"p1 at start", NewCurve(vec2.T{0, 0}, vec2.T{0, 0}, vec2.T{3, 4}, vec2.T{4, 0}), 0, vec2.T{-0.8, 0.6}},
+		{"p2 at end", NewCurve(vec2.T{0, 0}, vec2.T{0, 1}, vec2.T{4, 0}, vec2.T{4, 0}), 1, vec2.T{1 / math.Sqrt(17), 4 / math.Sqrt(17)}},
+		{"p1 and p2 at start", NewCurve(vec2.T{0, 0}, vec2.T{0, 0}, vec2.T{0, 0}, vec2.T{0, 2}), 0, vec2.T{-1, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.NNormal(tt.t); !vecNear(got, tt.want, 1e-15) {
+				t.Errorf("NNormal(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCurveSubdivide(t *testing.T) {
 	v := NewCurve(vec2.T{0, 0}, vec2.T{0, 1}, vec2.T{2, 1}, vec2.T{2, 0})
 	want := []float64{0, 0.125, 0.25, 0.5, 0.75, 0.875, 1}
@@ -109,3 +163,128 @@ func TestCurveSubdivide(t *testing.T) {
 // 		}
 // 	}
 // }
+
+func TestCurveLength(t *testing.T) {
+	v := NewCurve(vec2.T{0, 0}, vec2.T{0, 1}, vec2.T{2, 1}, vec2.T{2, 0})
+	var want float64
+	prev := v.At(0)
+	const n = 100000
+	for i := 1; i <= n; i++ {
+		p := v.At(float64(i) / n)
+		d := vec2.Sub(&p, &prev)
+		want += d.Length()
+		prev = p
+	}
+	got := v.Length()
+	if math.Abs(got-want) > 1e-8 {
+		t.Errorf("Length failed: got %v, want %v", got, want)
+	}
+}
+
+func TestCurveAtLength(t *testing.T) {
+	v := NewCurve(vec2.T{0, 0}, vec2.T{0, 1}, vec2.T{2, 1}, vec2.T{2, 0})
+	total := v.Length()
+	for _, f := range []float64{0, 0.1, 0.25, 0.5, 0.9, 1} {
+		s := f * total
+		tt := v.AtLength(s)
+		if got := arcLength(v, 0, tt, DefaultLengthTolerance); math.Abs(got-s) > 1e-7 {
+			t.Errorf("AtLength(%v) = %v: arc length %v, want %v", s, tt, got, s)
+		}
+	}
+	// The curve is symmetric, so half its length is at t=0.5.
+	if got := v.AtLength(0.5 * total); math.Abs(got-0.5) > 1e-7 {
+		t.Errorf("AtLength(half) = %v, want 0.5", got)
+	}
+}
+
+func TestArcLength_Tolerance(t *testing.T) {
+	v := NewCurve(vec2.T{0, 0}, vec2.T{0, 1}, vec2.T{2, 1}, vec2.T{2, 0})
+	total := v.Length()
+	for _, tol := range []float64{1e-3, 1e-6, 1e-12} {
+		if got := ArcLength(v, tol); math.Abs(got-total) > tol*total+1e-12 {
+			t.Errorf("ArcLength(%v) = %v, want %v", tol, got, total)
+		}
+		s := 0.3 * total
+		tt := AtArcLength(v, s, tol)
+		if got := arcLength(v, 0, tt, 1e-12); math.Abs(got-s) > 2*tol*total+1e-12 {
+			t.Errorf("AtArcLength(%v, %v): arc length %v, want %v", s, tol, got, s)
+		}
+	}
+}
+
+func TestCurveBevel_TightConcave(t *testing.T) {
+	v := NewCurve(vec2.T{0, 0}, vec2.T{0, 1}, vec2.T{2, 1}, vec2.T{2, 0})
+	num := len(v.Subdivide(10))
+	// Offsetting into the concave side by more than the radius of curvature
+	// makes the offset points of interior slices overlap.
+	for _, offset := range []float64{0.1, 1, 2} {
+		prevNN, nextNN := v.NNormal(0), v.NNormal(1)
+		prevNN.Invert()
+		nextNN.Invert()
+		tris, pts, err := v.Bevel(0, offset, 45, 10, true, &prevNN, &nextNN)
+		if err != nil {
+			t.Fatalf("Bevel(offset=%v): %v", offset, err)
+		}
+		if len(pts) == 0 || len(pts) > num-1 {
+			t.Errorf("Bevel(offset=%v) = %v bevel points, want 1..%v", offset, len(pts), num-1)
+		}
+		for _, tri := range tris {
+			for _, p := range tri {
+				if math.IsNaN(p[0]) || math.IsNaN(p[1]) || math.IsNaN(p[2]) {
+					t.Fatalf("Bevel(offset=%v) produced NaN vertex: %v", offset, tri)
+				}
+			}
+		}
+	}
+}
+
+func TestCurveBevel_Cusp(t *testing.T) {
+	// The tangent vanishes at t=0.5, so the normal is undefined there.
+	v := NewCurve(vec2.T{0, 0}, vec2.T{2, 1}, vec2.T{0, 1}, vec2.T{2, 0})
+	prevNN, nextNN := v.NNormal(0), v.NNormal(1)
+	if _, _, err := v.Bevel(0, 0.1, 45, 10, false, &prevNN, &nextNN); !errors.Is(err, ErrUndefinedNormal) {
+		t.Errorf("Bevel = %v, want ErrUndefinedNormal", err)
+	}
+}
+
+// checkSplitAndSub verifies that the pieces returned by Split and Sub
+// trace the same points as the original segment.
+func checkSplitAndSub(t *testing.T, seg T) {
+	t.Helper()
+	const e = 1e-12
+	for _, split := range []float64{0.25, 0.5, 0.8} {
+		a, b := seg.Split(split)
+		for _, u := range []float64{0, 0.3, 0.5, 1} {
+			if got, want := a.At(u), seg.At(u*split); !vecNear(got, want, e) {
+				t.Errorf("Split(%v) first.At(%v) = %v, want %v", split, u, got, want)
+			}
+			if got, want := b.At(u), seg.At(split+u*(1-split)); !vecNear(got, want, e) {
+				t.Errorf("Split(%v) second.At(%v) = %v, want %v", split, u, got, want)
+			}
+		}
+	}
+	for _, r := range [][2]float64{{0, 1}, {0.2, 0.7}, {0.9, 0.1}} {
+		sub := seg.Sub(r[0], r[1])
+		for _, u := range []float64{0, 0.3, 0.5, 1} {
+			if got, want := sub.At(u), seg.At(r[0]+u*(r[1]-r[0])); !vecNear(got, want, e) {
+				t.Errorf("Sub(%v, %v).At(%v) = %v, want %v", r[0], r[1], u, got, want)
+			}
+		}
+	}
+}
+
+func TestCurveSplitAndSub(t *testing.T) {
+	checkSplitAndSub(t, NewCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, -2}, vec2.T{3, 0}))
+}
+
+func TestCurvePoints(t *testing.T) {
+	v := NewCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, -2}, vec2.T{3, 0})
+	p0, p1, p2, p3 := v.Points()
+	if p0 != (vec2.T{0, 0}) || p1 != (vec2.T{1, 2}) || p2 != (vec2.T{2, -2}) || p3 != (vec2.T{3, 0}) {
+		t.Errorf("Points = %v, %v, %v, %v", p0, p1, p2, p3)
+	}
+	a, _ := v.Split(0.5)
+	if _, _, _, m := a.(Curve).Points(); m != v.At(0.5) {
+		t.Errorf("Split(0.5) endpoint = %v, want %v", m, v.At(0.5))
+	}
+}
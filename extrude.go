@@ -0,0 +1,106 @@
+package parametric2d
+
+import (
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// ExtrudeOptions describes the solid built by Path.Extrude.
+type ExtrudeOptions struct {
+	// Height is the height of the walls, which rise from the floor at z=0.
+	Height float64
+	// Bevel is the profile of the bevel on top of the walls, or nil if the
+	// top of the walls is capped flat.
+	Bevel *BevelProfile
+	// MaxDegrees determines the smoothness of the solid along the path.
+	MaxDegrees float64
+}
+
+// Extrude returns a closed solid built over the solid regions of the path
+// (see Wall): a floor at z=0, walls up to opts.Height, the bevel described
+// by opts.Bevel (see ProfileBevel) and a cap over the top. The parts share
+// their edges so that the solid is watertight, and every triangle faces
+// out of the solid. The solid is determined by the Path's FillRule alone,
+// whatever the directions and FlipNormals of its SubPaths. Unlike Wall
+// and Bevel, Extrude does not record FloorPts or BevelPts in the SubPaths.
+func (p *Path) Extrude(opts ExtrudeOptions) ([]Triangle3D, error) {
+	if len(p.SubPaths) == 0 {
+		return []Triangle3D{}, nil
+	}
+	pts := []vec2.T{{0, 0}}
+	if opts.Bevel != nil {
+		var err error
+		if pts, err = opts.Bevel.points(opts.MaxDegrees); err != nil {
+			return nil, err
+		}
+	}
+	p, err := p.prepare(opts.MaxDegrees)
+	if err != nil {
+		return nil, err
+	}
+	topZ := opts.Height + pts[len(pts)-1][1]
+	index := make(map[*SubPath]int, len(p.SubPaths))
+	for i, sp := range p.SubPaths {
+		index[sp] = i
+	}
+	r := make([]Triangle3D, 0, 100)
+	for _, g := range p.nest(opts.MaxDegrees) {
+		g.orient(index)
+		members := append([]*SubPath{g.outer}, g.holes...)
+		rings := make([][]vec2.T, len(members))
+		for i, sp := range members {
+			rings[i] = sp.solidRing(opts.MaxDegrees)
+		}
+		n := len(r)
+		r = capGroup(r, rings, 0)
+		faceUp(r[n:], false)
+
+		if opts.Height != 0 {
+			for _, ring := range rings {
+				r = append(r, stitchRings(ring3D(ring, 0), ring3D(ring, opts.Height))...)
+			}
+		}
+
+		n = len(r)
+		if opts.Bevel == nil {
+			r = capGroup(r, rings, topZ)
+		} else if r, _, err = g.profileBevel(r, pts, opts.Height, opts.MaxDegrees, index); err != nil {
+			return nil, err
+		}
+		faceUp(r[n:], true)
+	}
+	return r, nil
+}
+
+// orient replaces the SubPaths of the group whose normals do not face the
+// solid (judged from their signed areas) with copies whose normals do,
+// and adds the copies to 'index' in place of the SubPaths they replace.
+func (g *subPathGroup) orient(index map[*SubPath]int) {
+	facing := func(sp *SubPath, outer bool) *SubPath {
+		flip := (sp.Orientation() == Clockwise) == outer
+		if sp.FlipNormals == flip {
+			return sp
+		}
+		cp := *sp
+		cp.FlipNormals = flip
+		index[&cp] = index[sp]
+		return &cp
+	}
+	g.outer = facing(g.outer, true)
+	for i, hole := range g.holes {
+		g.holes[i] = facing(hole, false)
+	}
+}
+
+// faceUp reverses the flat triangles that do not face up, or down if 'up'
+// is false. Sloped and vertical triangles are left as they are.
+func faceUp(tris []Triangle3D, up bool) {
+	for _, t := range tris {
+		if t[0][2] != t[1][2] || t[0][2] != t[2][2] {
+			continue
+		}
+		a, b, c := vec2.T{t[0][0], t[0][1]}, vec2.T{t[1][0], t[1][1]}, vec2.T{t[2][0], t[2][1]}
+		if (cross3(a, b, c) > 0) != up {
+			t[1], t[2] = t[2], t[1]
+		}
+	}
+}
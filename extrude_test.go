@@ -0,0 +1,210 @@
+package parametric2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// meshVolume returns the volume enclosed by the triangles, which is
+// positive if they face out of the solid.
+func meshVolume(tris []Triangle3D) float64 {
+	var v float64
+	for _, t := range tris {
+		a, b, c := t[0], t[1], t[2]
+		v += a[0]*(b[1]*c[2]-b[2]*c[1]) - a[1]*(b[0]*c[2]-b[2]*c[0]) + a[2]*(b[0]*c[1]-b[1]*c[0])
+	}
+	return v / 6
+}
+
+func TestPathExtrude(t *testing.T) {
+	tests := []struct {
+		name string
+		d    string
+		opts ExtrudeOptions
+		want float64 // volume, or 0 to only check that it is positive
+	}{
+		{
+			name: "square",
+			d:    "M0 0h10v10h-10z",
+			opts: ExtrudeOptions{Height: 5, MaxDegrees: 10},
+			want: 500,
+		},
+		{
+			name: "square with hole",
+			d:    "M0 0h10v10h-10z M3 3v4h4v-4z",
+			opts: ExtrudeOptions{Height: 2, MaxDegrees: 10},
+			want: 168,
+		},
+		{
+			name: "chamfered square",
+			d:    "M0 0h10v10h-10z",
+			opts: ExtrudeOptions{Height: 5, Bevel: LinearProfile(1, 45), MaxDegrees: 10},
+			want: 500 + (100+64+80)/3.0,
+		},
+		{
+			name: "hip roof",
+			d:    "M0 0h10v2h-10z",
+			opts: ExtrudeOptions{Height: 1, Bevel: LinearProfile(2, 45), MaxDegrees: 10},
+			want: 20 + 4*(30-2)/12.0,
+		},
+		{
+			name: "bevel only",
+			d:    "M0 0h10v10h-10z",
+			opts: ExtrudeOptions{Bevel: StepProfile(2, 2, 2), MaxDegrees: 10},
+			want: 100 + 64,
+		},
+		{
+			name: "U roof",
+			d:    "M0 0h10v7h-3v-4h-4.5v5h-2.5z",
+			opts: ExtrudeOptions{Height: 1, Bevel: LinearProfile(3, 30), MaxDegrees: 10},
+		},
+		{
+			name: "L roof",
+			d:    "M0 0h8v3h-5v6h-3z",
+			opts: ExtrudeOptions{Height: 1, Bevel: CoveProfile(2), MaxDegrees: 10},
+		},
+		{
+			name: "rounded circle",
+			d:    "M-5 0A5 5 0 1 0 5 0A5 5 0 1 0 -5 0z",
+			opts: ExtrudeOptions{Height: 3, Bevel: RoundProfile(1), MaxDegrees: 10},
+		},
+		{
+			name: "stepped star",
+			d:    "M5 0L6.2 3.5L10 3.5L7 5.8L8.1 9.5L5 7.2L1.9 9.5L3 5.8L0 3.5L3.8 3.5z",
+			opts: ExtrudeOptions{Height: 1, Bevel: StepProfile(3, 1.5, 1.5), MaxDegrees: 10},
+		},
+		{
+			name: "ogee letter O",
+			d:    "M0 0h6v8h-6z M2 2v4h2v-4z",
+			opts: ExtrudeOptions{Height: 2, Bevel: OgeeProfile(1.2, 1), MaxDegrees: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustParseSVGPath(t, tt.d)
+			p.AutoFlipNormals()
+			tris, err := p.Extrude(tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report := ValidateMesh(tris, 1e-6); !report.IsValid() {
+				t.Errorf("Extrude mesh: %v", report)
+			}
+			v := meshVolume(tris)
+			if tt.want != 0 && math.Abs(v-tt.want) > 1e-6*tt.want {
+				t.Errorf("Extrude volume = %v, want %v", v, tt.want)
+			}
+			if v <= 0 {
+				t.Errorf("Extrude volume = %v, want > 0", v)
+			}
+		})
+	}
+}
+
+func TestPathExtrude_Orientation(t *testing.T) {
+	tests := []struct {
+		name string
+		d    string
+		flip []bool // FlipNormals of each SubPath
+		opts ExtrudeOptions
+		want float64
+	}{
+		{
+			name: "clockwise square",
+			d:    "M0 0v10h10v-10z",
+			opts: ExtrudeOptions{Height: 5, MaxDegrees: 10},
+			want: 500,
+		},
+		{
+			name: "clockwise chamfered square",
+			d:    "M0 0v10h10v-10z",
+			opts: ExtrudeOptions{Height: 5, Bevel: LinearProfile(1, 45), MaxDegrees: 10},
+			want: 500 + (100+64+80)/3.0,
+		},
+		{
+			name: "flipped square",
+			d:    "M0 0h10v10h-10z",
+			flip: []bool{true},
+			opts: ExtrudeOptions{Height: 5, Bevel: LinearProfile(1, 45), MaxDegrees: 10},
+			want: 500 + (100+64+80)/3.0,
+		},
+		{
+			name: "clockwise square with counter-clockwise hole",
+			d:    "M0 0v10h10v-10z M3 3h4v4h-4z",
+			opts: ExtrudeOptions{Height: 2, Bevel: LinearProfile(1, 45), MaxDegrees: 10},
+			want: 168 + (100+64+80)/3.0 - (16+36+24)/3.0,
+		},
+		{
+			name: "square with flipped hole in the same direction",
+			d:    "M0 0h10v10h-10z M3 3h4v4h-4z",
+			flip: []bool{false, true},
+			opts: ExtrudeOptions{Height: 2, MaxDegrees: 10},
+			want: 168,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustParseSVGPath(t, tt.d)
+			for i, flip := range tt.flip {
+				p.SubPaths[i].FlipNormals = flip
+			}
+			tris, err := p.Extrude(tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report := ValidateMesh(tris, 1e-6); !report.IsValid() {
+				t.Errorf("Extrude mesh: %v", report)
+			}
+			if v := meshVolume(tris); math.Abs(v-tt.want) > 1e-6*tt.want {
+				t.Errorf("Extrude volume = %v, want %v", v, tt.want)
+			}
+			for i, flip := range tt.flip {
+				if p.SubPaths[i].FlipNormals != flip {
+					t.Errorf("Extrude changed FlipNormals of subpath #%v", i)
+				}
+			}
+		})
+	}
+}
+
+func TestPathExtrude_Empty(t *testing.T) {
+	tris, err := (&Path{}).Extrude(ExtrudeOptions{Height: 1, MaxDegrees: 10})
+	if err != nil || len(tris) != 0 {
+		t.Errorf("Extrude = %v, %v, want no triangles", tris, err)
+	}
+}
+
+func TestPathExtrude_InvalidProfile(t *testing.T) {
+	p := &Path{SubPaths: []*SubPath{circleSubPath(vec2.T{0, 0}, 5, true)}}
+	bad := NewBevelProfile(NewLine(vec2.T{1, 0}, vec2.T{2, 1}))
+	if _, err := p.Extrude(ExtrudeOptions{Height: 1, Bevel: bad, MaxDegrees: 10}); err == nil {
+		t.Error("Extrude with an invalid profile returned no error")
+	}
+}
+
+func TestPathExtrude_Repeated(t *testing.T) {
+	p := mustParseSVGPath(t, "M0 0h10v10h-10z")
+	p.AutoFlipNormals()
+	opts := ExtrudeOptions{Height: 5, Bevel: LinearProfile(1, 45), MaxDegrees: 10}
+	if _, err := p.Bevel(5, 1, 45, 10); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		tris, err := p.Extrude(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, want := meshVolume(tris), 500+(100+64+80)/3.0; math.Abs(v-want) > 1e-6*want {
+			t.Errorf("Extrude #%v volume = %v, want %v", i+1, v, want)
+		}
+	}
+	if _, err := p.Bevel(5, 1, 45, 10); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(p.SubPaths[0].BevelPts); got != 4 {
+		t.Errorf("BevelPts after repeated calls has %v points, want 4", got)
+	}
+}
@@ -0,0 +1,634 @@
+package parametric2d
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// FillRule determines which regions enclosed by a Path's subpaths are solid.
+type FillRule int
+
+const (
+	// EvenOdd treats a region as solid if the subpaths enclose it an odd
+	// number of times. It is the default.
+	EvenOdd FillRule = iota
+	// NonZero treats a region as solid if the subpaths wind around it
+	// a nonzero number of times, taking their directions into account.
+	NonZero
+)
+
+// inside returns true if a region with winding number 'w' is solid.
+func (r FillRule) inside(w int) bool {
+	if r == NonZero {
+		return w != 0
+	}
+	return w%2 != 0
+}
+
+// SolidRegions returns a Path whose subpaths are the boundaries of the
+// solid regions of the Path according to its FillRule.
+//
+// The subpaths are flattened using `maxDegrees` and split wherever they
+// cross or overlap one another (or themselves), and only the pieces that
+// separate a solid region from an empty one are kept. Subpaths that bound
+// a solid region without touching any other subpath are returned
+// unchanged. All other boundaries are rebuilt from the parts of the
+// original segments between crossings, and wind counter-clockwise around
+// solids and clockwise around holes with normals that face the solid.
+//
+// If no subpath needs to change, the Path itself is returned. Otherwise
+// the rebuilt subpaths are new, so the methods that extrude the solid
+// regions (such as Wall and Bevel) record FloorPts, BevelPts and IsOuter
+// in them rather than in the subpaths they replace.
+func (p *Path) SolidRegions(maxDegrees float64) *Path {
+	contours := flattenContours(p.SubPaths, 0, maxDegrees)
+	ar := newArrangement(contours)
+	loops := ar.boundary(func(w [2]int) bool { return p.FillRule.inside(w[0]) })
+	subPaths, _, reused := ar.subPaths(loops, contours)
+	if reused == len(subPaths) && reused == len(p.SubPaths) {
+		return p
+	}
+	return &Path{SubPaths: subPaths, FillRule: p.FillRule, OnSelfIntersection: p.OnSelfIntersection}
+}
+
+// edgeSource records which segment of its contour's SubPath (and which
+// range of 't' along it) a flattened edge came from.
+type edgeSource struct {
+	segment int
+	t0, t1  float64
+}
+
+// contour is a flattened SubPath.
+type contour struct {
+	sp  *SubPath
+	pts []vec2.T
+	// srcs[i] is the source of the edge from pts[i] to pts[i+1].
+	srcs    []edgeSource
+	operand int
+}
+
+// flattenContours flattens each SubPath into a closed polygon tagged with
+// the given operand (which distinguishes the inputs of boolean operations).
+func flattenContours(sps []*SubPath, operand int, maxDegrees float64) []*contour {
+	var r []*contour
+	for _, sp := range sps {
+		c := &contour{sp: sp, operand: operand}
+		for j, seg := range sp.Segments {
+			ts := subdivisionTs(seg, maxDegrees)
+			for k := 0; k < len(ts)-1; k++ {
+				c.pts = append(c.pts, seg.At(ts[k]))
+				c.srcs = append(c.srcs, edgeSource{segment: j, t0: ts[k], t1: ts[k+1]})
+			}
+		}
+		r = append(r, c)
+	}
+	return r
+}
+
+// arrEdge is a directed edge of an arrangement.
+type arrEdge struct {
+	u, v    int
+	operand int
+	contour int
+	src     edgeSource
+	// whole is true if the edge is an entire, unsplit edge of its contour.
+	whole bool
+}
+
+// arrangement is a set of contours whose edges have been split at every
+// crossing or overlap so that edges only meet at shared vertices.
+type arrangement struct {
+	eps   float64
+	verts []vec2.T
+	cells map[[2]int64][]int
+	edges []arrEdge
+	// byContour lists the edges of each contour, and boxes holds their
+	// bounding boxes, which are bucketed in a grid of boxCell-sized cells
+	// (except for those that span many cells, which are kept in wide).
+	byContour [][]int
+	boxes     []vec2.Rect
+	boxCell   float64
+	boxCells  map[[2]int64][]int
+	wide      []int
+}
+
+// rawEdge is an edge of a contour before it has been split.
+type rawEdge struct {
+	a, b    vec2.T
+	contour int
+	src     edgeSource
+	bbox    vec2.Rect
+}
+
+// splitPoint is a point at parameter 't' along a rawEdge.
+type splitPoint struct {
+	t  float64
+	pt vec2.T
+}
+
+// newArrangement builds the arrangement of the given contours.
+func newArrangement(contours []*contour) *arrangement {
+	var raw []rawEdge
+	var bbox vec2.Rect
+	for ci, c := range contours {
+		for i, a := range c.pts {
+			b := c.pts[(i+1)%len(c.pts)]
+			e := rawEdge{a: a, b: b, contour: ci, src: c.srcs[i], bbox: vec2.NewRect(&a, &b)}
+			if len(raw) == 0 {
+				bbox = e.bbox
+			} else {
+				bbox = vec2.Joined(&bbox, &e.bbox)
+			}
+			raw = append(raw, e)
+		}
+	}
+	size := math.Max(bbox.Max[0]-bbox.Min[0], bbox.Max[1]-bbox.Min[1])
+	ar := &arrangement{eps: 1e-9 * math.Max(1, size), cells: map[[2]int64][]int{}}
+
+	splits := make([][]splitPoint, len(raw))
+	order := make([]int, len(raw))
+	for i, e := range raw {
+		order[i] = i
+		splits[i] = []splitPoint{{0, e.a}, {1, e.b}}
+	}
+	// Sweep along x so that only edges with overlapping extents are compared.
+	sort.Slice(order, func(i, j int) bool { return raw[order[i]].bbox.Min[0] < raw[order[j]].bbox.Min[0] })
+	for oi, i := range order {
+		ei := &raw[i]
+		for _, j := range order[oi+1:] {
+			ej := &raw[j]
+			if ej.bbox.Min[0] > ei.bbox.Max[0]+ar.eps {
+				break
+			}
+			if ej.bbox.Min[1] > ei.bbox.Max[1]+ar.eps || ei.bbox.Min[1] > ej.bbox.Max[1]+ar.eps {
+				continue
+			}
+			for _, x := range edgeIntersections(ei.a, ei.b, ej.a, ej.b, ar.eps) {
+				splits[i] = append(splits[i], splitPoint{x.t, x.pt})
+				splits[j] = append(splits[j], splitPoint{x.u, x.pt})
+			}
+		}
+	}
+
+	for i, e := range raw {
+		sp := splits[i]
+		sort.Slice(sp, func(a, b int) bool { return sp[a].t < sp[b].t })
+		whole := true
+		for _, s := range sp {
+			if v := ar.vertex(s.pt); v != ar.vertex(e.a) && v != ar.vertex(e.b) {
+				whole = false
+			}
+		}
+		prev, prevT := ar.vertex(sp[0].pt), sp[0].t
+		for _, s := range sp[1:] {
+			v := ar.vertex(s.pt)
+			if v == prev {
+				continue
+			}
+			src := e.src
+			src.t0 = e.src.t0 + (e.src.t1-e.src.t0)*prevT
+			src.t1 = e.src.t0 + (e.src.t1-e.src.t0)*s.t
+			ar.edges = append(ar.edges, arrEdge{
+				u:       prev,
+				v:       v,
+				operand: contours[e.contour].operand,
+				contour: e.contour,
+				src:     src,
+				whole:   whole,
+			})
+			prev, prevT = v, s.t
+		}
+	}
+
+	ar.byContour = make([][]int, len(contours))
+	ar.boxes = make([]vec2.Rect, len(contours))
+	for i, e := range ar.edges {
+		c := e.contour
+		box := vec2.NewRect(&ar.verts[e.u], &ar.verts[e.v])
+		if len(ar.byContour[c]) > 0 {
+			box = vec2.Joined(&ar.boxes[c], &box)
+		}
+		ar.byContour[c] = append(ar.byContour[c], i)
+		ar.boxes[c] = box
+	}
+	g := math.Ceil(math.Sqrt(float64(len(contours))))
+	ar.boxCell = math.Max(size/g, ar.eps)
+	ar.boxCells = map[[2]int64][]int{}
+	for c, edges := range ar.byContour {
+		if len(edges) == 0 {
+			continue
+		}
+		lo := ar.boxKey(vec2.T{ar.boxes[c].Min[0] - ar.eps, ar.boxes[c].Min[1] - ar.eps})
+		hi := ar.boxKey(vec2.T{ar.boxes[c].Max[0] + ar.eps, ar.boxes[c].Max[1] + ar.eps})
+		if float64((hi[0]-lo[0]+1)*(hi[1]-lo[1]+1)) > g {
+			ar.wide = append(ar.wide, c)
+			continue
+		}
+		for x := lo[0]; x <= hi[0]; x++ {
+			for y := lo[1]; y <= hi[1]; y++ {
+				k := [2]int64{x, y}
+				ar.boxCells[k] = append(ar.boxCells[k], c)
+			}
+		}
+	}
+	return ar
+}
+
+// boxKey returns the cell of the grid of contour boxes that holds pt.
+func (ar *arrangement) boxKey(pt vec2.T) [2]int64 {
+	return [2]int64{int64(math.Floor(pt[0] / ar.boxCell)), int64(math.Floor(pt[1] / ar.boxCell))}
+}
+
+// vertex returns the index of the vertex within eps of pt, adding a new
+// vertex if there is none.
+func (ar *arrangement) vertex(pt vec2.T) int {
+	k := [2]int64{int64(math.Floor(pt[0] / ar.eps)), int64(math.Floor(pt[1] / ar.eps))}
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for _, i := range ar.cells[[2]int64{k[0] + dx, k[1] + dy}] {
+				d := vec2.Sub(&ar.verts[i], &pt)
+				if d.LengthSqr() <= ar.eps*ar.eps {
+					return i
+				}
+			}
+		}
+	}
+	i := len(ar.verts)
+	ar.verts = append(ar.verts, pt)
+	ar.cells[k] = append(ar.cells[k], i)
+	return i
+}
+
+// edgeIntersection is a point where two edges meet, at parameter 't'
+// along the first edge and 'u' along the second.
+type edgeIntersection struct {
+	t, u float64
+	pt   vec2.T
+}
+
+// edgeIntersections returns the points where edge a-b meets edge c-d
+// (within eps). Collinear overlapping edges return both ends of their
+// overlap.
+func edgeIntersections(a, b, c, d vec2.T, eps float64) []edgeIntersection {
+	d1 := vec2.Sub(&b, &a)
+	d2 := vec2.Sub(&d, &c)
+	l1, l2 := d1.Length(), d2.Length()
+	if l1 == 0 || l2 == 0 {
+		return nil
+	}
+	ca := vec2.Sub(&c, &a)
+	den := cross2(d1, d2)
+	if math.Abs(den) > 1e-12*l1*l2 {
+		t := cross2(ca, d2) / den
+		u := cross2(ca, d1) / den
+		if t < -eps/l1 || t > 1+eps/l1 || u < -eps/l2 || u > 1+eps/l2 {
+			return nil
+		}
+		t, u = clamp01(t), clamp01(u)
+		return []edgeIntersection{{t: t, u: u, pt: vec2.Interpolate(&a, &b, t)}}
+	}
+	// Parallel: only collinear edges can meet.
+	if math.Abs(cross2(d1, ca))/l1 > eps {
+		return nil
+	}
+	var r []edgeIntersection
+	add := func(t, u float64, pt vec2.T) {
+		if t < -eps/l1 || t > 1+eps/l1 || u < -eps/l2 || u > 1+eps/l2 {
+			return
+		}
+		r = append(r, edgeIntersection{t: clamp01(t), u: clamp01(u), pt: pt})
+	}
+	project := func(p, o, dir vec2.T, l float64) float64 {
+		v := vec2.Sub(&p, &o)
+		return vec2.Dot(&v, &dir) / (l * l)
+	}
+	add(project(c, a, d1, l1), 0, c)
+	add(project(d, a, d1, l1), 1, d)
+	add(0, project(a, c, d2, l2), a)
+	add(1, project(b, c, d2, l2), b)
+	return r
+}
+
+func clamp01(t float64) float64 {
+	return math.Max(0, math.Min(1, t))
+}
+
+// arrLoop is a closed boundary traced through an arrangement. Each
+// edge refers to ar.edges and 'reversed' records whether it is
+// traversed from v to u.
+type arrLoop struct {
+	edges    []int
+	reversed []bool
+}
+
+// boundary returns the loops that separate the regions for which
+// 'inside' is true from the rest of the plane. 'inside' is given the
+// winding number of a region with respect to the contours of each
+// operand. The loops are oriented so that the inside lies to their left
+// (counter-clockwise around solids and clockwise around holes).
+func (ar *arrangement) boundary(inside func(w [2]int) bool) []arrLoop {
+	// Group coincident edges regardless of their direction.
+	groups := map[[2]int][]int{}
+	var keys [][2]int
+	for i, e := range ar.edges {
+		k := [2]int{e.u, e.v}
+		if k[0] > k[1] {
+			k[0], k[1] = k[1], k[0]
+		}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], i)
+	}
+
+	type halfEdge struct {
+		edge     int
+		reversed bool
+		from, to int
+	}
+	var kept []halfEdge
+	for _, k := range keys {
+		group := groups[k]
+		// Winding numbers just to the right of the directed edge k[0]->k[1],
+		// and the amount by which they increase on its left.
+		right := ar.windingRightOf(k[0], k[1], group)
+		var left [2]int
+		for op := range left {
+			left[op] = right[op]
+		}
+		for _, i := range group {
+			e := &ar.edges[i]
+			if e.u == k[0] {
+				left[e.operand]++
+			} else {
+				left[e.operand]--
+			}
+		}
+		in := inside(left)
+		if in == inside(right) {
+			continue
+		}
+		// Prefer an edge that already runs in the kept direction.
+		h := halfEdge{edge: group[0]}
+		from, to := k[0], k[1]
+		if !in {
+			from, to = to, from
+		}
+		for _, i := range group {
+			if ar.edges[i].u == from {
+				h.edge = i
+				break
+			}
+		}
+		h.reversed = ar.edges[h.edge].u != from
+		h.from, h.to = from, to
+		kept = append(kept, h)
+	}
+
+	out := map[int][]int{}
+	for i, h := range kept {
+		out[h.from] = append(out[h.from], i)
+	}
+	used := make([]bool, len(kept))
+	var loops []arrLoop
+	for start := range kept {
+		if used[start] {
+			continue
+		}
+		var loop arrLoop
+		cur := start
+		for {
+			used[cur] = true
+			h := kept[cur]
+			loop.edges = append(loop.edges, h.edge)
+			loop.reversed = append(loop.reversed, h.reversed)
+			// Leave each vertex along the outgoing edge that turns most
+			// sharply to the right, so that regions which only touch at
+			// a vertex become separate loops.
+			back := vec2.Sub(&ar.verts[h.from], &ar.verts[h.to])
+			backAngle := math.Atan2(back[1], back[0])
+			next, best := -1, math.Inf(1)
+			for _, i := range out[h.to] {
+				if used[i] && i != start {
+					continue
+				}
+				d := vec2.Sub(&ar.verts[kept[i].to], &ar.verts[h.to])
+				cw := math.Mod(backAngle-math.Atan2(d[1], d[0])+4*math.Pi, 2*math.Pi)
+				if cw == 0 {
+					cw = 2 * math.Pi
+				}
+				if cw < best {
+					next, best = i, cw
+				}
+			}
+			if next < 0 || next == start {
+				break
+			}
+			cur = next
+		}
+		if len(loop.edges) >= 3 {
+			loops = append(loops, loop)
+		}
+	}
+	return loops
+}
+
+// windingRightOf returns the winding number (with respect to the
+// contours of each operand) of the region immediately to the right of
+// the directed edge u->v. The edges in 'exclude' lie along u->v.
+//
+// A ray is cast from the midpoint of the edge to its right. Because
+// edges only meet at vertices, no other edge passes through the
+// midpoint, so the count is exact. The edges of each contour form a
+// closed loop, which cannot wind around a point outside its bounding box,
+// so only the contours whose boxes hold the midpoint are counted.
+func (ar *arrangement) windingRightOf(u, v int, exclude []int) [2]int {
+	m := vec2.Interpolate(&ar.verts[u], &ar.verts[v], 0.5)
+	d := vec2.Sub(&ar.verts[v], &ar.verts[u])
+	// Work in a frame whose +x axis points along the ray.
+	dir := vec2.T{d[1], -d[0]}
+	dir.Normalize()
+	perp := vec2.T{-dir[1], dir[0]}
+	frame := func(p vec2.T) vec2.T {
+		q := vec2.Sub(&p, &m)
+		return vec2.T{vec2.Dot(&q, &dir), vec2.Dot(&q, &perp)}
+	}
+	skip := map[int]bool{}
+	for _, i := range exclude {
+		skip[i] = true
+	}
+	var w [2]int
+	origin := vec2.T{}
+	for _, cs := range [][]int{ar.boxCells[ar.boxKey(m)], ar.wide} {
+		for _, c := range cs {
+			box := ar.boxes[c]
+			if m[0] < box.Min[0]-ar.eps || m[0] > box.Max[0]+ar.eps ||
+				m[1] < box.Min[1]-ar.eps || m[1] > box.Max[1]+ar.eps {
+				continue
+			}
+			for _, i := range ar.byContour[c] {
+				if skip[i] {
+					continue
+				}
+				e := &ar.edges[i]
+				a, b := frame(ar.verts[e.u]), frame(ar.verts[e.v])
+				if a[1] <= 0 {
+					if b[1] > 0 && cross3(a, b, origin) > 0 {
+						w[e.operand]++
+					}
+				} else if b[1] <= 0 && cross3(a, b, origin) < 0 {
+					w[e.operand]--
+				}
+			}
+		}
+	}
+	return w
+}
+
+// subPaths converts the loops into SubPaths. A loop that consists of
+// every edge of a single, unsplit contour is replaced by the contour's
+// original SubPath, and the number of such loops is returned along with
+// whether each SubPath runs against its loop (which keeps the inside on its
+// left). All other loops are rebuilt from the parts of the original
+// segments between the points where the loop changes from one segment to
+// another.
+func (ar *arrangement) subPaths(loops []arrLoop, contours []*contour) (r []*SubPath, reversed []bool, reused int) {
+	edgeCount := make([]int, len(contours))
+	for _, e := range ar.edges {
+		edgeCount[e.contour]++
+	}
+	for _, loop := range loops {
+		if ci, ok := ar.wholeContour(loop, contours, edgeCount); ok {
+			r = append(r, contours[ci].sp)
+			reversed = append(reversed, loop.reversed[0])
+			reused++
+			continue
+		}
+		r = append(r, ar.loopSubPath(loop, contours))
+		reversed = append(reversed, false)
+	}
+	return r, reversed, reused
+}
+
+// loopRun is a maximal sequence of consecutive loop edges that follow
+// the same original segment, running from 't0' to 't1' along it.
+type loopRun struct {
+	contour, segment int
+	t0, t1           float64
+	// span is the range of 't' covered by the flattened edges at each end
+	// of the run, which limits how far the ends may be refined.
+	span0, span1 float64
+}
+
+func (ar *arrangement) loopRun(loop arrLoop, i int) loopRun {
+	e := ar.edges[loop.edges[i]]
+	r := loopRun{contour: e.contour, segment: e.src.segment, t0: e.src.t0, t1: e.src.t1}
+	if loop.reversed[i] {
+		r.t0, r.t1 = r.t1, r.t0
+	}
+	r.span0 = math.Abs(r.t1 - r.t0)
+	r.span1 = r.span0
+	return r
+}
+
+// extend appends 'next' to the run if it continues along the same segment.
+func (r *loopRun) extend(next loopRun) bool {
+	if next.contour != r.contour || next.segment != r.segment ||
+		math.Abs(next.t0-r.t1) > 1e-12 || (next.t1 > next.t0) != (r.t1 > r.t0) {
+		return false
+	}
+	r.t1, r.span1 = next.t1, next.span1
+	return true
+}
+
+// loopSubPath rebuilds a loop from the original segments. Where the loop
+// passes from one segment to another at a crossing, the crossing was only
+// computed between flattened edges, so it is refined onto the segments
+// themselves.
+func (ar *arrangement) loopSubPath(loop arrLoop, contours []*contour) *SubPath {
+	n := len(loop.edges)
+	// Start at the beginning of a run.
+	start := 0
+	for i := 0; i < n; i++ {
+		prev := ar.loopRun(loop, (i+n-1)%n)
+		if !prev.extend(ar.loopRun(loop, i)) {
+			start = i
+			break
+		}
+	}
+	var runs []loopRun
+	for k := 0; k < n; k++ {
+		next := ar.loopRun(loop, (start+k)%n)
+		if len(runs) == 0 || !runs[len(runs)-1].extend(next) {
+			runs = append(runs, next)
+		}
+	}
+	seg := func(r loopRun) T { return contours[r.contour].sp.Segments[r.segment] }
+	for i := range runs {
+		a, b := &runs[i], &runs[(i+1)%len(runs)]
+		if a.contour == b.contour && (a.t1 == 0 || a.t1 == 1) && (b.t0 == 0 || b.t0 == 1) {
+			continue // the runs meet where the original segments do
+		}
+		s, t := refineIntersection(seg(*a), seg(*b), a.t1, b.t0)
+		if math.Abs(s-a.t1) <= a.span1 && math.Abs(t-b.t0) <= b.span0 {
+			a.t1, b.t0 = s, t
+		}
+	}
+	sp := &SubPath{}
+	for _, r := range runs {
+		if r.t0 != r.t1 {
+			sp.Segments = append(sp.Segments, seg(r).Sub(r.t0, r.t1))
+		}
+	}
+	return sp
+}
+
+// wholeContour returns the index of the contour if the loop consists of
+// all of its edges, none of which were split.
+func (ar *arrangement) wholeContour(loop arrLoop, contours []*contour, edgeCount []int) (int, bool) {
+	ci := ar.edges[loop.edges[0]].contour
+	if len(loop.edges) != edgeCount[ci] || edgeCount[ci] != len(contours[ci].pts) {
+		return 0, false
+	}
+	for _, ei := range loop.edges {
+		if e := ar.edges[ei]; e.contour != ci || !e.whole {
+			return 0, false
+		}
+	}
+	return ci, true
+}
+
+// resolvePolygon splits the closed polygon wherever it crosses itself
+// and returns the loops that bound the regions for which 'inside' is
+// true, given the winding number of each region.
+func resolvePolygon(pts []vec2.T, inside func(w int) bool) [][]vec2.T {
+	srcs := make([]edgeSource, len(pts))
+	ar := newArrangement([]*contour{{pts: pts, srcs: srcs}})
+	var r [][]vec2.T
+	for _, loop := range ar.boundary(func(w [2]int) bool { return inside(w[0]) }) {
+		poly := make([]vec2.T, len(loop.edges))
+		for i, ei := range loop.edges {
+			e := ar.edges[ei]
+			poly[i] = ar.verts[e.u]
+			if loop.reversed[i] {
+				poly[i] = ar.verts[e.v]
+			}
+		}
+		r = append(r, poly)
+	}
+	return r
+}
+
+// nestedRings reports whether the closed polygons, each with the solid on
+// its left, neither cross nor touch one another or themselves, so that
+// they bound the solid without any overlaps.
+func nestedRings(rings [][]vec2.T) bool {
+	var contours []*contour
+	for _, ring := range rings {
+		contours = append(contours, &contour{pts: ring, srcs: make([]edgeSource, len(ring))})
+	}
+	ar := newArrangement(contours)
+	return len(ar.boundary(func(w [2]int) bool { return w[0] > 0 })) == len(rings)
+}
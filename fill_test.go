@@ -0,0 +1,137 @@
+package parametric2d
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPathSolidRegions(t *testing.T) {
+	tests := []struct {
+		name      string
+		d         string
+		rule      FillRule
+		wantAreas []float64 // signed area of each resulting subpath, in any order
+		unchanged bool
+	}{
+		{
+			name:      "square with reversed hole",
+			d:         "M0 0h10v10h-10z M2 2v6h6v-6z",
+			rule:      NonZero,
+			wantAreas: []float64{100, -36},
+			unchanged: true,
+		},
+		{
+			name:      "nested squares same direction even-odd",
+			d:         "M0 0h10v10h-10z M2 2h6v6h-6z",
+			rule:      EvenOdd,
+			wantAreas: []float64{100, 36},
+			unchanged: true,
+		},
+		{
+			name:      "nested squares same direction nonzero",
+			d:         "M0 0h10v10h-10z M2 2h6v6h-6z",
+			rule:      NonZero,
+			wantAreas: []float64{100},
+		},
+		{
+			name:      "overlapping squares nonzero",
+			d:         "M0 0h2v2h-2z M1 1h2v2h-2z",
+			rule:      NonZero,
+			wantAreas: []float64{7},
+		},
+		{
+			name:      "overlapping squares even-odd",
+			d:         "M0 0h2v2h-2z M1 1h2v2h-2z",
+			rule:      EvenOdd,
+			wantAreas: []float64{3, 3},
+		},
+		{
+			name:      "opposite overlapping squares nonzero",
+			d:         "M0 0h2v2h-2z M1 1v2h2v-2z",
+			rule:      NonZero,
+			wantAreas: []float64{3, 3},
+		},
+		{
+			name:      "bowtie",
+			d:         "M0 0L2 2L2 0L0 2z",
+			rule:      NonZero,
+			wantAreas: []float64{1, 1},
+		},
+		{
+			name:      "shared edge",
+			d:         "M0 0h2v2h-2z M2 0h2v2h-2z",
+			rule:      NonZero,
+			wantAreas: []float64{8},
+		},
+		{
+			name:      "squares inside a frame",
+			d:         "M0 0h10v10h-10z M1 1h2v2h-2z M4 1h2v2h-2z M7 1h2v2h-2z M1 4h2v2h-2z M4 4h2v2h-2z M7 4h2v2h-2z M1 7h2v2h-2z M4 7h2v2h-2z M7 7h2v2h-2z",
+			rule:      EvenOdd,
+			wantAreas: []float64{100, 4, 4, 4, 4, 4, 4, 4, 4, 4},
+			unchanged: true,
+		},
+		{
+			name:      "squares across a frame",
+			d:         "M0 0h10v10h-10z M-1 4h2v2h-2z M9 4h2v2h-2z M4 4h2v2h-2z",
+			rule:      NonZero,
+			wantAreas: []float64{104},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustParseSVGPath(t, tt.d)
+			p.FillRule = tt.rule
+			got := p.SolidRegions(10)
+			if (got == p) != tt.unchanged {
+				t.Errorf("SolidRegions returned the original path = %v, want %v", got == p, tt.unchanged)
+			}
+			if len(got.SubPaths) != len(tt.wantAreas) {
+				t.Fatalf("SolidRegions = %v subpaths, want %v", len(got.SubPaths), len(tt.wantAreas))
+			}
+			used := make([]bool, len(tt.wantAreas))
+			for i, sp := range got.SubPaths {
+				a := sp.SignedArea()
+				found := false
+				for j, want := range tt.wantAreas {
+					if !used[j] && math.Abs(a-want) < 1e-9 {
+						used[j], found = true, true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("subpath #%v signed area = %v, want one of %v", i, a, tt.wantAreas)
+				}
+			}
+		})
+	}
+}
+
+func TestPathWall_FillRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        string
+		rule     FillRule
+		wantArea float64
+		e        float64
+	}{
+		{"nested squares even-odd", "M0 0h10v10h-10z M2 2h6v6h-6z", EvenOdd, 64, 1e-9},
+		{"nested squares nonzero", "M0 0h10v10h-10z M2 2h6v6h-6z", NonZero, 100, 1e-9},
+		{"overlapping squares nonzero", "M0 0h2v2h-2z M1 1h2v2h-2z", NonZero, 7, 1e-9},
+		{"overlapping squares even-odd", "M0 0h2v2h-2z M1 1h2v2h-2z", EvenOdd, 6, 1e-9},
+		// Two circles of radius 2 whose centers are 2 apart.
+		{"overlapping circles nonzero", "M0 0a2 2 0 1 0 4 0a2 2 0 1 0 -4 0z M2 0a2 2 0 1 0 4 0a2 2 0 1 0 -4 0z", NonZero, 16*math.Pi/3 + 2*math.Sqrt(3), 0.05},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustParseSVGPath(t, tt.d)
+			p.FillRule = tt.rule
+			tris, err := p.Wall(1, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := projectedArea(tris); math.Abs(got-tt.wantArea) > tt.e {
+				t.Errorf("floor area = %v, want %v", got, tt.wantArea)
+			}
+		})
+	}
+}
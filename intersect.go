@@ -0,0 +1,316 @@
+package parametric2d
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// Intersection is a point where two segments meet.
+type Intersection struct {
+	// T0 and T1 are the parametric positions of the point along the first
+	// and second segment, respectively.
+	T0, T1 float64
+	Point  vec2.T
+}
+
+// Intersect returns all points where segments 'a' and 'b' meet, ordered
+// by their position along 'a'. Segments that touch tangentially meet at
+// a single point. Where the segments overlap (such as collinear lines),
+// the ends of the overlapping range are returned.
+//
+// Lines are intersected exactly with each other, with Bezier curves (by
+// solving for the roots of the curve's distance from the line) and with
+// arcs. All other pairs are intersected by recursively subdividing both
+// segments until their bounding boxes are small, and then refining each
+// candidate with Newton's method.
+func Intersect(a, b T) []Intersection {
+	ba, bb := a.BBox(), b.BBox()
+	bbox := vec2.Joined(&ba, &bb)
+	eps := 1e-9 * math.Max(1, math.Max(bbox.Max[0]-bbox.Min[0], bbox.Max[1]-bbox.Min[1]))
+	if !bboxesOverlap(ba, bb, eps) {
+		return nil
+	}
+	var r []Intersection
+	switch {
+	case a.IsLine() && b.IsLine():
+		r = intersectLines(a, b, eps)
+	case a.IsLine():
+		r = intersectLine(a, b, eps)
+	case b.IsLine():
+		r = swapIntersections(intersectLine(b, a, eps))
+	default:
+		r = intersectCurves(a, b, eps)
+	}
+	return sortIntersections(r, eps)
+}
+
+func bboxesOverlap(a, b vec2.Rect, eps float64) bool {
+	return a.Min[0] <= b.Max[0]+eps && b.Min[0] <= a.Max[0]+eps &&
+		a.Min[1] <= b.Max[1]+eps && b.Min[1] <= a.Max[1]+eps
+}
+
+func swapIntersections(r []Intersection) []Intersection {
+	for i := range r {
+		r[i].T0, r[i].T1 = r[i].T1, r[i].T0
+	}
+	return r
+}
+
+// sortIntersections orders the intersections along the first segment and
+// removes duplicates.
+func sortIntersections(r []Intersection, eps float64) []Intersection {
+	sort.Slice(r, func(i, j int) bool { return r[i].T0 < r[j].T0 })
+	var out []Intersection
+	for _, x := range r {
+		dup := false
+		for _, y := range out {
+			if distance(x.Point, y.Point) <= 10*eps {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// intersectLines intersects two line segments.
+func intersectLines(a, b T, eps float64) []Intersection {
+	var r []Intersection
+	for _, x := range edgeIntersections(a.At(0), a.At(1), b.At(0), b.At(1), eps) {
+		r = append(r, Intersection{T0: x.t, T1: x.u, Point: x.pt})
+	}
+	return r
+}
+
+// intersectLine intersects the line segment 'line' with the curved
+// segment 'seg'.
+func intersectLine(line, seg T, eps float64) []Intersection {
+	p0, p1 := line.At(0), line.At(1)
+	d := vec2.Sub(&p1, &p0)
+	l := d.Length()
+	if l == 0 {
+		return nil
+	}
+	var ts []float64
+	switch s := seg.(type) {
+	case Curve:
+		ts = lineBezierRoots(p0, d, []vec2.T{s.spline.P0, s.spline.P1, s.spline.P2, s.spline.P3}, eps)
+	case QuadCurve:
+		ts = lineBezierRoots(p0, d, []vec2.T{s.spline.P0, s.spline.P1, s.spline.P2}, eps)
+	case Arc:
+		ts = lineArcRoots(p0, d, s, eps)
+	default:
+		return swapIntersections(intersectCurves(seg, line, eps))
+	}
+	var r []Intersection
+	for _, t := range ts {
+		pt := seg.At(t)
+		v := vec2.Sub(&pt, &p0)
+		u := vec2.Dot(&v, &d) / (l * l)
+		if u < -eps/l || u > 1+eps/l {
+			continue
+		}
+		r = append(r, Intersection{T0: clamp01(u), T1: t, Point: pt})
+	}
+	return r
+}
+
+// lineBezierRoots returns the positions along the Bezier curve with
+// control points 'pts' where it meets the infinite line through p0 in
+// direction d. The signed distance of the curve from the line is a
+// polynomial in 't', so its roots are found directly. Points where the
+// curve only touches the line are found among the roots of its derivative.
+func lineBezierRoots(p0, d vec2.T, pts []vec2.T, eps float64) []float64 {
+	// f(t) = d x (B(t) - p0) / |d|
+	l := d.Length()
+	bx, by := bezierPoly(pts, 0), bezierPoly(pts, 1)
+	bx[0] -= p0[0]
+	by[0] -= p0[1]
+	f := make([]float64, len(bx))
+	for i := range f {
+		f[i] = (d[0]*by[i] - d[1]*bx[i]) / l
+	}
+	if allNear(f, eps) {
+		// The curve lies along the line; return its ends.
+		return []float64{0, 1}
+	}
+	ts := polyRoots(f, 0, 1)
+	for _, t := range append(polyRoots(polyDeriv(f), 0, 1), 0, 1) {
+		if math.Abs(polyEval(f, t)) <= eps {
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
+
+func allNear(c []float64, eps float64) bool {
+	for _, v := range c {
+		if math.Abs(v) > eps {
+			return false
+		}
+	}
+	return true
+}
+
+// lineArcRoots returns the positions along the Arc where it meets the
+// infinite line through p0 in direction d. The line is mapped into the
+// frame in which the Arc's ellipse is the unit circle, where the
+// intersections are the roots of a quadratic.
+func lineArcRoots(p0, d vec2.T, s Arc, eps float64) []float64 {
+	if s.rx == 0 || s.ry == 0 {
+		return nil
+	}
+	inv := NewScaling(1/s.rx, 1/s.ry).Mul(NewRotation(-s.rotation * 180.0 / math.Pi)).Mul(NewTranslation(-s.center[0], -s.center[1]))
+	q := inv.Apply(p0)
+	v := inv.applyVector(d)
+	// |q + u*v|^2 = 1
+	a := vec2.Dot(&v, &v)
+	b := 2 * vec2.Dot(&q, &v)
+	c := vec2.Dot(&q, &q) - 1
+	us := quadraticRoots(a, b, c)
+	if len(us) == 0 {
+		// The line may only graze the ellipse.
+		u := -b / (2 * a)
+		m := vec2.T{q[0] + u*v[0], q[1] + u*v[1]}
+		if math.Abs(m.Length()-1)*math.Min(s.rx, s.ry) <= eps {
+			us = []float64{u}
+		}
+	}
+	var ts []float64
+	for _, u := range us {
+		m := vec2.T{q[0] + u*v[0], q[1] + u*v[1]}
+		if t, ok := s.angleToT(math.Atan2(m[1], m[0])); ok {
+			ts = append(ts, t)
+			continue
+		}
+		// Allow for rounding at the ends of the Arc.
+		pt := vec2.T{p0[0] + u*d[0], p0[1] + u*d[1]}
+		if distance(pt, s.At(0)) <= 10*eps {
+			ts = append(ts, 0)
+		} else if distance(pt, s.At(1)) <= 10*eps {
+			ts = append(ts, 1)
+		}
+	}
+	return ts
+}
+
+// intersectLeafSize is the size (relative to the segments) below which
+// intersectCurves stops subdividing.
+const intersectLeafSize = 1e-4
+
+// intersectCurves intersects two arbitrary segments by subdivision.
+func intersectCurves(a, b T, eps float64) []Intersection {
+	if r, ok := overlap(a, b, eps); ok {
+		return r
+	}
+	ba, bb := a.BBox(), b.BBox()
+	bbox := vec2.Joined(&ba, &bb)
+	leaf := intersectLeafSize * math.Max(bbox.Max[0]-bbox.Min[0], bbox.Max[1]-bbox.Min[1])
+	var r []Intersection
+	var recurse func(a0, a1, b0, b1 float64, depth int)
+	recurse = func(a0, a1, b0, b1 float64, depth int) {
+		sa, sb := a.Sub(a0, a1), b.Sub(b0, b1)
+		ra, rb := sa.BBox(), sb.BBox()
+		if !bboxesOverlap(ra, rb, eps) {
+			return
+		}
+		sizeA := math.Max(ra.Max[0]-ra.Min[0], ra.Max[1]-ra.Min[1])
+		sizeB := math.Max(rb.Max[0]-rb.Min[0], rb.Max[1]-rb.Min[1])
+		if (sizeA <= leaf && sizeB <= leaf) || depth >= 40 {
+			s, t := 0.5, 0.5
+			if x := edgeIntersections(sa.At(0), sa.At(1), sb.At(0), sb.At(1), leaf); len(x) > 0 {
+				s, t = x[0].t, x[0].u
+			}
+			s, t = refineIntersection(a, b, a0+s*(a1-a0), b0+t*(b1-b0))
+			pa, pb := a.At(s), b.At(t)
+			if distance(pa, pb) <= 100*eps {
+				r = append(r, Intersection{T0: s, T1: t, Point: vec2.Interpolate(&pa, &pb, 0.5)})
+			}
+			return
+		}
+		// Split the larger of the two.
+		if sizeA >= sizeB {
+			m := 0.5 * (a0 + a1)
+			recurse(a0, m, b0, b1, depth+1)
+			recurse(m, a1, b0, b1, depth+1)
+		} else {
+			m := 0.5 * (b0 + b1)
+			recurse(a0, a1, b0, m, depth+1)
+			recurse(a0, a1, m, b1, depth+1)
+		}
+	}
+	recurse(0, 1, 0, 1, 0)
+	return r
+}
+
+// refineIntersection improves an estimate of where a(s) = b(t) using the
+// Levenberg-Marquardt method, which also converges (more slowly) where
+// the segments are tangent.
+func refineIntersection(a, b T, s, t float64) (float64, float64) {
+	lambda := 1e-12
+	for i := 0; i < 50; i++ {
+		pa, pb := a.At(s), b.At(t)
+		f := vec2.Sub(&pa, &pb)
+		if f.IsZero() {
+			break
+		}
+		da, db := a.Tangent(s), b.Tangent(t)
+		// J = [da, -db]; solve (J^T J + lambda I) delta = -J^T f.
+		m00 := vec2.Dot(&da, &da) + lambda
+		m01 := -vec2.Dot(&da, &db)
+		m11 := vec2.Dot(&db, &db) + lambda
+		g0 := -vec2.Dot(&da, &f)
+		g1 := vec2.Dot(&db, &f)
+		det := m00*m11 - m01*m01
+		if det == 0 {
+			break
+		}
+		ns := clamp01(s + (m11*g0-m01*g1)/det)
+		nt := clamp01(t + (m00*g1-m01*g0)/det)
+		qa, qb := a.At(ns), b.At(nt)
+		if distance(qa, qb) >= f.Length() {
+			lambda *= 10
+			if lambda > 1e6 {
+				break
+			}
+			continue
+		}
+		lambda *= 0.1
+		s, t = ns, nt
+	}
+	return s, t
+}
+
+// overlap detects segments that trace the same path over some range
+// and returns the ends of that range.
+func overlap(a, b T, eps float64) ([]Intersection, bool) {
+	tol := 100 * eps
+	var ends []Intersection
+	for _, s := range []float64{0, 1} {
+		if t, d := b.ClosestPoint(a.At(s)); d <= tol {
+			ends = append(ends, Intersection{T0: s, T1: t, Point: a.At(s)})
+		}
+		if t, d := a.ClosestPoint(b.At(s)); d <= tol {
+			ends = append(ends, Intersection{T0: t, T1: s, Point: b.At(s)})
+		}
+	}
+	if len(ends) < 2 {
+		return nil, false
+	}
+	sort.Slice(ends, func(i, j int) bool { return ends[i].T0 < ends[j].T0 })
+	first, last := ends[0], ends[len(ends)-1]
+	if last.T0-first.T0 < 1e-6 {
+		return nil, false
+	}
+	for _, f := range []float64{0.25, 0.5, 0.75} {
+		if _, d := b.ClosestPoint(a.At(first.T0 + f*(last.T0-first.T0))); d > tol {
+			return nil, false
+		}
+	}
+	return []Intersection{first, last}, true
+}
@@ -0,0 +1,60 @@
+package parametric2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+func TestIntersect(t *testing.T) {
+	arch := NewCurve(vec2.T{0, 0}, vec2.T{0, 1}, vec2.T{2, 1}, vec2.T{2, 0})
+	sCurve := NewCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, -2}, vec2.T{3, 0})
+	circle := NewArc(vec2.T{0, 0}, 1, 1, 0, 0, 360)
+	flat := NewCurve(vec2.T{0, 0.5}, vec2.T{1, 0.5}, vec2.T{2, 0.5}, vec2.T{3, 0.5})
+	hump := NewCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 2}, vec2.T{3, 0})
+	r := (1 - math.Sqrt(2.0/3)) / 2
+	tests := []struct {
+		name string
+		a, b T
+		want []vec2.T
+	}{
+		{"crossing lines", NewLine(vec2.T{0, 0}, vec2.T{2, 2}), NewLine(vec2.T{0, 2}, vec2.T{2, 0}), []vec2.T{{1, 1}}},
+		{"parallel lines", NewLine(vec2.T{0, 0}, vec2.T{2, 0}), NewLine(vec2.T{0, 1}, vec2.T{2, 1}), nil},
+		{"disjoint lines", NewLine(vec2.T{0, 0}, vec2.T{1, 1}), NewLine(vec2.T{0, 3}, vec2.T{3, 2}), nil},
+		{"collinear lines", NewLine(vec2.T{0, 0}, vec2.T{4, 0}), NewLine(vec2.T{6, 0}, vec2.T{2, 0}), []vec2.T{{2, 0}, {4, 0}}},
+		{"touching lines", NewLine(vec2.T{0, 0}, vec2.T{2, 0}), NewLine(vec2.T{1, 0}, vec2.T{1, 5}), []vec2.T{{1, 0}}},
+		{"line and curve", NewLine(vec2.T{-1, 0}, vec2.T{4, 0}), sCurve, []vec2.T{{0, 0}, {1.5, 0}, {3, 0}}},
+		{"curve and line", sCurve, NewLine(vec2.T{-1, 0}, vec2.T{4, 0}), []vec2.T{{0, 0}, {1.5, 0}, {3, 0}}},
+		{"tangent line", NewLine(vec2.T{-1, 0.75}, vec2.T{3, 0.75}), arch, []vec2.T{{1, 0.75}}},
+		{"line and quadcurve", NewLine(vec2.T{0, 0.5}, vec2.T{2, 0.5}), NewQuadCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0}), []vec2.T{{1 - math.Sqrt(0.5), 0.5}, {1 + math.Sqrt(0.5), 0.5}}},
+		{"line and circle", NewLine(vec2.T{-2, 0}, vec2.T{2, 0}), circle, []vec2.T{{-1, 0}, {1, 0}}},
+		{"tangent to circle", NewLine(vec2.T{-2, 1}, vec2.T{2, 1}), circle, []vec2.T{{0, 1}}},
+		{"line short of circle", NewLine(vec2.T{-0.5, 0}, vec2.T{0.5, 0}), circle, nil},
+		{"curves", hump, flat, []vec2.T{hump.At(r), hump.At(1 - r)}},
+		{"tangent curves", arch, NewCurve(vec2.T{0, 1.5}, vec2.T{0, 0.5}, vec2.T{2, 0.5}, vec2.T{2, 1.5}), []vec2.T{{1, 0.75}}},
+		// With s = t - 1/2, the S curve is (4.5s - 2s^3, 18s^3 - 4.5s), which
+		// meets the unit circle where s^2 is the real root of
+		// 328u^3 - 180u^2 + 40.5u - 1 = 0, u = 0.0279974270200546511739...
+		{"curve and circle", NewCurve(vec2.T{-2, 0}, vec2.T{-1, 3}, vec2.T{1, -3}, vec2.T{2, 0}), circle,
+			[]vec2.T{{-0.74359012526818599, 0.66863571965880156}, {0.74359012526818599, -0.66863571965880156}}},
+		{"overlapping curves", sCurve, sCurve.Sub(0.3, 0.8), []vec2.T{sCurve.At(0.3), sCurve.At(0.8)}},
+		{"separate curves", arch, NewCurve(vec2.T{5, 0}, vec2.T{5, 1}, vec2.T{7, 1}, vec2.T{7, 0}), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Intersect(tt.a, tt.b)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Intersect = %v, want %v", got, tt.want)
+			}
+			for i, x := range got {
+				if !vecNear(x.Point, tt.want[i], 1e-7) {
+					t.Errorf("Intersect #%v = %v, want %v", i, x.Point, tt.want[i])
+				}
+				if pa, pb := tt.a.At(x.T0), tt.b.At(x.T1); !vecNear(pa, x.Point, 1e-7) || !vecNear(pb, x.Point, 1e-7) {
+					t.Errorf("Intersect #%v = %+v, but a.At(T0) = %v and b.At(T1) = %v", i, x, pa, pb)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package parametric2d
+
+import "math"
+
+// DefaultLengthTolerance is the relative tolerance used by the Length and
+// AtLength methods of the segments when measuring arc lengths and when
+// inverting arc length back to the parametric 't' value.
+const DefaultLengthTolerance = 1e-9
+
+// maxLengthDepth limits the recursion of the adaptive quadrature.
+const maxLengthDepth = 24
+
+// 5-point Gauss-Legendre abscissae and weights on [-1,1].
+var (
+	glAbscissae = [5]float64{
+		0,
+		-0.5384693101056831, 0.5384693101056831,
+		-0.9061798459386640, 0.9061798459386640,
+	}
+	glWeights = [5]float64{
+		0.5688888888888889,
+		0.4786286704993665, 0.4786286704993665,
+		0.2369268850561891, 0.2369268850561891,
+	}
+)
+
+// gaussLegendre integrates the speed of the segment between t0 and t1.
+func gaussLegendre(seg T, t0, t1 float64) float64 {
+	half := 0.5 * (t1 - t0)
+	mid := 0.5 * (t0 + t1)
+	var sum float64
+	for i, x := range glAbscissae {
+		v := seg.Tangent(mid + half*x)
+		sum += glWeights[i] * v.Length()
+	}
+	return sum * half
+}
+
+// ArcLength returns the arc length of the segment, measured by adaptive
+// Gauss-Legendre quadrature to within the relative tolerance 'tol'.
+func ArcLength(seg T, tol float64) float64 {
+	return arcLength(seg, 0, 1, tol)
+}
+
+// AtArcLength returns the parametric 't' value at which the arc length
+// of the segment measured from t=0 equals 'l', to within the relative
+// tolerance 'tol'.
+func AtArcLength(seg T, l, tol float64) float64 {
+	return tAtLength(seg, arcLength(seg, 0, 1, tol), l, tol)
+}
+
+// arcLength returns the arc length of the segment between t0 and t1
+// using adaptive Gauss-Legendre quadrature.
+func arcLength(seg T, t0, t1, tol float64) float64 {
+	if t1 <= t0 {
+		return 0
+	}
+	whole := gaussLegendre(seg, t0, t1)
+	return adaptiveArcLength(seg, t0, t1, whole, tol*math.Max(whole, 1e-12), 0)
+}
+
+func adaptiveArcLength(seg T, t0, t1, whole, tol float64, depth int) float64 {
+	m := 0.5 * (t0 + t1)
+	left := gaussLegendre(seg, t0, m)
+	right := gaussLegendre(seg, m, t1)
+	if depth >= maxLengthDepth || math.Abs(left+right-whole) <= tol {
+		return left + right
+	}
+	return adaptiveArcLength(seg, t0, m, left, 0.5*tol, depth+1) +
+		adaptiveArcLength(seg, m, t1, right, 0.5*tol, depth+1)
+}
+
+// tAtLength inverts the arc length of a segment whose total length is
+// 'total', returning the 't' value at which the length from t=0 is 's'.
+// It uses Newton's method safeguarded by bisection, and keeps a running
+// length so that each step only measures the arc it moved along.
+func tAtLength(seg T, total, s, tol float64) float64 {
+	if s <= 0 || total <= 0 {
+		return 0
+	}
+	if s >= total {
+		return 1
+	}
+	lo, hi := 0.0, 1.0
+	t := s / total
+	l := arcLength(seg, 0, t, tol)
+	for i := 0; i < 100; i++ {
+		f := l - s
+		if math.Abs(f) <= tol*total {
+			return t
+		}
+		if f < 0 {
+			lo = t
+		} else {
+			hi = t
+		}
+		v := seg.Tangent(t)
+		next := 0.5 * (lo + hi)
+		if speed := v.Length(); speed > 0 {
+			if n := t - f/speed; n > lo && n < hi {
+				next = n
+			}
+		}
+		if next > t {
+			l += arcLength(seg, t, next, tol)
+		} else {
+			l -= arcLength(seg, next, t, tol)
+		}
+		t = next
+	}
+	return t
+}
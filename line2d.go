@@ -59,7 +59,10 @@ func (s Line) NNormal(t float64) vec2.T {
 }
 
 // Wall extrudes a line into a 3D wall. `maxDegrees` is ignored.
-func (s Line) Wall(height, maxDegrees float64, flipNormals bool) ([]Triangle3D, poly2tri.PointArray) {
+func (s Line) Wall(height, maxDegrees float64, flipNormals bool) ([]Triangle3D, poly2tri.PointArray, error) {
+	if s.p0 == s.p1 {
+		return nil, nil, ErrDegenerateSegment
+	}
 	p0 := s.At(0)
 	p1 := s.At(1)
 	t0 := Triangle3D{
@@ -78,11 +81,14 @@ func (s Line) Wall(height, maxDegrees float64, flipNormals bool) ([]Triangle3D,
 	if flipNormals {
 		t1[1], t1[2] = t1[2], t1[1]
 	}
-	return []Triangle3D{t0, t1}, poly2tri.PointArray{poly2tri.NewPoint(p1[0], p1[1])}
+	return []Triangle3D{t0, t1}, poly2tri.PointArray{poly2tri.NewPoint(p1[0], p1[1])}, nil
 }
 
 // Bevel returns a 3D beveled object based on the provided Line.
-func (s Line) Bevel(height, offset, deg, maxDegrees float64, flipNormals bool, prevNN, nextNN *vec2.T) ([]Triangle3D, poly2tri.PointArray) {
+func (s Line) Bevel(height, offset, deg, maxDegrees float64, flipNormals bool, prevNN, nextNN *vec2.T) ([]Triangle3D, poly2tri.PointArray, error) {
+	if s.p0 == s.p1 {
+		return nil, nil, ErrDegenerateSegment
+	}
 	h := offset * math.Tan(deg*math.Pi/180.0)
 	p0 := s.At(0)
 	p1 := s.At(1)
@@ -91,16 +97,14 @@ func (s Line) Bevel(height, offset, deg, maxDegrees float64, flipNormals bool, p
 	if flipNormals {
 		n0[0], n0[1], n1[0], n1[1] = -n0[0], -n0[1], -n1[0], -n1[1]
 	}
-	angle0 := vec2.Angle(prevNN, &n0)
+	angle0 := normalAngle(prevNN, &n0)
 	newLength0 := offset / math.Cos(0.5*angle0)
-	angle1 := vec2.Angle(&n1, nextNN)
+	angle1 := normalAngle(&n1, nextNN)
 	newLength1 := offset / math.Cos(0.5*angle1)
-	prevNN.Add(&n0)
-	prevNN.Normalize()
-	nextNN.Add(&n1)
-	nextNN.Normalize()
-	p2 := prevNN.Scale(newLength0).Add(&p0)
-	p3 := nextNN.Scale(newLength1).Add(&p1)
+	m0 := vec2.Add(prevNN, &n0)
+	m1 := vec2.Add(&n1, nextNN)
+	p2 := m0.Normalize().Scale(newLength0).Add(&p0)
+	p3 := m1.Normalize().Scale(newLength1).Add(&p1)
 	t0 := Triangle3D{
 		vec3.T{p0[0], p0[1], height},
 		vec3.T{p3[0], p3[1], height + h},
@@ -117,10 +121,63 @@ func (s Line) Bevel(height, offset, deg, maxDegrees float64, flipNormals bool, p
 	if flipNormals {
 		t1[1], t1[2] = t1[2], t1[1]
 	}
-	return []Triangle3D{t0, t1}, poly2tri.PointArray{poly2tri.NewPoint(p3[0], p3[1])}
+	return []Triangle3D{t0, t1}, poly2tri.PointArray{poly2tri.NewPoint(p3[0], p3[1])}, nil
 }
 
 // IsLine is true for type Line.
 func (s Line) IsLine() bool {
 	return true
 }
+
+// Reverse returns the Line from p1 to p0.
+func (s Line) Reverse() T {
+	return Line{p0: s.p1, p1: s.p0, bbox: s.bbox}
+}
+
+// Points returns the start and end points of the Line.
+func (s Line) Points() (p0, p1 vec2.T) {
+	return s.p0, s.p1
+}
+
+// Split divides the Line at position 't'.
+func (s Line) Split(t float64) (T, T) {
+	return s.Sub(0, t), s.Sub(t, 1)
+}
+
+// Sub returns the part of the Line between positions 't0' and 't1'.
+func (s Line) Sub(t0, t1 float64) T {
+	return NewLine(s.At(t0), s.At(t1))
+}
+
+// Transform returns the Line transformed by 'm'.
+func (s Line) Transform(m Affine) T {
+	return NewLine(m.Apply(s.p0), m.Apply(s.p1))
+}
+
+// ClosestPoint returns the position along the Line nearest to 'p' and
+// its distance from 'p'.
+func (s Line) ClosestPoint(p vec2.T) (float64, float64) {
+	d := vec2.Sub(&s.p1, &s.p0)
+	v := vec2.Sub(&p, &s.p0)
+	var t float64
+	if l := d.LengthSqr(); l > 0 {
+		t = math.Max(0, math.Min(1, vec2.Dot(&v, &d)/l))
+	}
+	return t, distance(p, s.At(t))
+}
+
+// Length returns the length of the Line.
+func (s Line) Length() float64 {
+	v := vec2.Sub(&s.p1, &s.p0)
+	return v.Length()
+}
+
+// AtLength returns the position (0 <= t <= 1) at the given distance
+// along the Line.
+func (s Line) AtLength(l float64) float64 {
+	total := s.Length()
+	if total == 0 {
+		return 0
+	}
+	return math.Max(0, math.Min(1, l/total))
+}
@@ -1,6 +1,7 @@
 package parametric2d
 
 import (
+	"errors"
 	"math"
 	"testing"
 
@@ -80,13 +81,19 @@ func TestWall(t *testing.T) {
 		{vec3.T{0, 0, 0}, vec3.T{1, 0, 4}, vec3.T{0, 0, 4}},
 		{vec3.T{0, 0, 0}, vec3.T{1, 0, 0}, vec3.T{1, 0, 4}},
 	}
-	got, _ := v.Wall(4, 1, false)
+	got, _, err := v.Wall(4, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
 	for i, tri := range got {
 		if tri[0] != want[i][0] || tri[1] != want[i][1] || tri[2] != want[i][2] {
 			t.Errorf("NNormal #%v failed: got %v, want %v", i, tri, want[i])
 		}
 	}
-	got, _ = v.Wall(4, 1, true)
+	got, _, err = v.Wall(4, 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
 	for i, tri := range got {
 		if tri[0] != want[i][0] || tri[1] != want[i][2] || tri[2] != want[i][1] {
 			t.Errorf("NNormal #%v failed: got %v, want [%v %v %v]", i, tri, want[i][0], want[i][2], want[i][1])
@@ -100,7 +107,10 @@ func TestBevel(t *testing.T) {
 		{vec3.T{0, 0, 4}, vec3.T{1, 1, 5}, vec3.T{0, 1, 5}},
 		{vec3.T{0, 0, 4}, vec3.T{1, 0, 4}, vec3.T{1, 1, 5}},
 	}
-	got, _ := v.Bevel(4, 1, 45, 1, false, &vec2.T{0, 1}, &vec2.T{0, 1})
+	got, _, err := v.Bevel(4, 1, 45, 1, false, &vec2.T{0, 1}, &vec2.T{0, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
 	for i, tri := range got {
 		if tri[0] != want[i][0] || tri[1] != want[i][1] || tri[2] != want[i][2] {
 			t.Errorf("NNormal #%v failed: got %v, want %v", i, tri, want[i])
@@ -117,3 +127,53 @@ func TestBevel(t *testing.T) {
 	// 	}
 	// }
 }
+
+func TestBevel_KeepsNeighborNormals(t *testing.T) {
+	v := NewLine(vec2.T{0, 0}, vec2.T{1, 0})
+	prevNN, nextNN := vec2.T{-1, 0}, vec2.T{1, 0}
+	if _, _, err := v.Bevel(4, 1, 45, 1, false, &prevNN, &nextNN); err != nil {
+		t.Fatal(err)
+	}
+	if want := (vec2.T{-1, 0}); prevNN != want {
+		t.Errorf("prevNN = %v, want %v", prevNN, want)
+	}
+	if want := (vec2.T{1, 0}); nextNN != want {
+		t.Errorf("nextNN = %v, want %v", nextNN, want)
+	}
+}
+
+func TestLength(t *testing.T) {
+	v := NewLine(vec2.T{0, 0}, vec2.T{3, 4})
+	if got, want := v.Length(), 5.0; got != want {
+		t.Errorf("Length failed: got %v, want %v", got, want)
+	}
+	if got, want := v.AtLength(2), 0.4; math.Abs(got-want) > 1e-15 {
+		t.Errorf("AtLength failed: got %v, want %v", got, want)
+	}
+}
+
+func TestDegenerateLine(t *testing.T) {
+	v := NewLine(vec2.T{1, 1}, vec2.T{1, 1})
+	if _, _, err := v.Wall(4, 1, false); err != ErrDegenerateSegment {
+		t.Errorf("Wall = %v, want ErrDegenerateSegment", err)
+	}
+	if _, _, err := v.Bevel(4, 1, 45, 1, false, &vec2.T{0, 1}, &vec2.T{0, 1}); err != ErrDegenerateSegment {
+		t.Errorf("Bevel = %v, want ErrDegenerateSegment", err)
+	}
+	sp := &SubPath{Segments: []T{NewLine(vec2.T{0, 0}, vec2.T{1, 1}), v, NewLine(vec2.T{1, 1}, vec2.T{0, 0})}}
+	if _, err := sp.Wall(4, 1); !errors.Is(err, ErrDegenerateSegment) {
+		t.Errorf("SubPath.Wall = %v, want ErrDegenerateSegment", err)
+	}
+}
+
+func TestLineSplitAndSub(t *testing.T) {
+	v := NewLine(vec2.T{1, 2}, vec2.T{5, -2})
+	checkSplitAndSub(t, v)
+	a, b := v.Split(0.25)
+	if _, p1 := a.(Line).Points(); p1 != (vec2.T{2, 1}) {
+		t.Errorf("Split(0.25) first end = %v, want [2 1]", p1)
+	}
+	if p0, p1 := b.(Line).Points(); p0 != (vec2.T{2, 1}) || p1 != (vec2.T{5, -2}) {
+		t.Errorf("Split(0.25) second = %v-%v, want [2 1]-[5 -2]", p0, p1)
+	}
+}
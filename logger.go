@@ -0,0 +1,56 @@
+package parametric2d
+
+import "sync/atomic"
+
+// Logger receives the package's diagnostic messages as a message followed
+// by alternating key/value pairs. It is satisfied by *slog.Logger.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+}
+
+// current holds the Logger set by SetLogger in a loggerHolder (as an
+// atomic.Value must always hold the same concrete type), so that it can
+// be changed while other goroutines are logging.
+var current atomic.Value
+
+type loggerHolder struct{ l Logger }
+
+// logger forwards the package's diagnostic messages to the current Logger.
+// It is silent by default.
+var logger packageLogger
+
+// SetLogger routes the package's diagnostic messages to 'l'.
+// Passing nil silences them again (the default). It is safe to call
+// while the package is in use by other goroutines.
+func SetLogger(l Logger) {
+	if l == nil {
+		l = nopLogger{}
+	}
+	current.Store(loggerHolder{l})
+}
+
+type packageLogger struct{}
+
+func (packageLogger) get() Logger {
+	if h, ok := current.Load().(loggerHolder); ok {
+		return h.l
+	}
+	return nopLogger{}
+}
+
+func (p packageLogger) Debug(msg string, args ...interface{}) { p.get().Debug(msg, args...) }
+func (p packageLogger) Warn(msg string, args ...interface{})  { p.get().Warn(msg, args...) }
+
+// enabled reports whether a Logger has been set, so that callers in hot
+// loops can skip building the arguments of messages that would be
+// discarded.
+func (p packageLogger) enabled() bool {
+	_, nop := p.get().(nopLogger)
+	return !nop
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, args ...interface{}) {}
+func (nopLogger) Warn(msg string, args ...interface{})  {}
@@ -0,0 +1,57 @@
+package parametric2d
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var _ Logger = slog.Default()
+
+func TestSetLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer SetLogger(nil)
+
+	p := mustParseSVGPath(t, "M0 0h10v10h-10z")
+	if _, err := p.Bevel(1, 1, 45, 10); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); !strings.Contains(got, "level=DEBUG") || !strings.Contains(got, `msg="capping rings" points=4`) {
+		t.Errorf("SetLogger: got %q, want structured debug messages", got)
+	}
+
+	buf.Reset()
+	SetLogger(nil)
+	if _, err := p.Bevel(1, 1, 45, 10); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "" {
+		t.Errorf("SetLogger(nil): got %q, want silence", got)
+	}
+}
+
+func TestSetLogger_Concurrent(t *testing.T) {
+	defer SetLogger(nil)
+	p := mustParseSVGPath(t, "M-5 0A5 5 0 1 0 5 0A5 5 0 1 0 -5 0z")
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			SetLogger(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+			SetLogger(nil)
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		if _, err := p.Bevel(1, 0.5, 45, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Wait()
+	if logger.enabled() {
+		t.Error("logger.enabled() = true after SetLogger(nil)")
+	}
+}
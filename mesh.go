@@ -0,0 +1,113 @@
+package parametric2d
+
+import (
+	"math"
+
+	"github.com/gmlewis/go3d/float64/vec3"
+)
+
+// Mesh represents an indexed triangle mesh whose triangles share vertices.
+type Mesh struct {
+	// Vertices holds the unique vertices of the mesh.
+	Vertices []vec3.T
+	// Faces holds the indices into Vertices of each triangle.
+	Faces [][3]int
+}
+
+// NewMesh converts a triangle soup (such as the output of Path.Wall and
+// Path.Bevel) into an indexed Mesh, welding together vertices that are
+// within 'epsilon' of each other. An epsilon of zero only welds identical
+// vertices. Triangles that collapse as a result of welding are dropped.
+func NewMesh(tris []Triangle3D, epsilon float64) *Mesh {
+	m := &Mesh{Faces: make([][3]int, 0, len(tris))}
+	w := newWelder(epsilon)
+	for _, t := range tris {
+		var f [3]int
+		for i, v := range t[:3] {
+			f[i] = w.index(m, v)
+		}
+		if f[0] == f[1] || f[1] == f[2] || f[2] == f[0] {
+			continue
+		}
+		m.Faces = append(m.Faces, f)
+	}
+	return m
+}
+
+// Triangles converts the Mesh back into a triangle soup.
+func (m *Mesh) Triangles() []Triangle3D {
+	r := make([]Triangle3D, 0, len(m.Faces))
+	for _, f := range m.Faces {
+		r = append(r, Triangle3D{m.Vertices[f[0]], m.Vertices[f[1]], m.Vertices[f[2]]})
+	}
+	return r
+}
+
+// Write writes each triangle of the Mesh to the TriangleWriter.
+func (m *Mesh) Write(tw TriangleWriter) error {
+	for _, f := range m.Faces {
+		if err := tw.WriteTriangle3D(Triangle3D{m.Vertices[f[0]], m.Vertices[f[1]], m.Vertices[f[2]]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type cellKey [3]int64
+
+// welder finds previously-seen vertices within epsilon using a uniform grid
+// whose cells are epsilon wide, so only the 27 neighboring cells need to be
+// searched.
+type welder struct {
+	epsilon float64
+	cells   map[cellKey][]int
+}
+
+func newWelder(epsilon float64) *welder {
+	return &welder{epsilon: math.Abs(epsilon), cells: map[cellKey][]int{}}
+}
+
+func (w *welder) key(v vec3.T) cellKey {
+	if w.epsilon == 0 {
+		return cellKey{
+			int64(math.Float64bits(v[0])),
+			int64(math.Float64bits(v[1])),
+			int64(math.Float64bits(v[2])),
+		}
+	}
+	return cellKey{
+		int64(math.Floor(v[0] / w.epsilon)),
+		int64(math.Floor(v[1] / w.epsilon)),
+		int64(math.Floor(v[2] / w.epsilon)),
+	}
+}
+
+// index returns the index of the vertex in the mesh, adding it if necessary.
+func (w *welder) index(m *Mesh, v vec3.T) int {
+	k := w.key(v)
+	if w.epsilon == 0 {
+		if idx, ok := w.cells[k]; ok {
+			return idx[0]
+		}
+	} else {
+		best, bestDist := -1, w.epsilon*w.epsilon
+		for dx := int64(-1); dx <= 1; dx++ {
+			for dy := int64(-1); dy <= 1; dy++ {
+				for dz := int64(-1); dz <= 1; dz++ {
+					for _, i := range w.cells[cellKey{k[0] + dx, k[1] + dy, k[2] + dz}] {
+						if d := vec3.SquareDistance(&m.Vertices[i], &v); d <= bestDist {
+							best, bestDist = i, d
+						}
+					}
+				}
+			}
+		}
+		if best >= 0 {
+			return best
+		}
+	}
+	i := len(m.Vertices)
+	m.Vertices = append(m.Vertices, v)
+	w.cells[k] = append(w.cells[k], i)
+	return i
+}
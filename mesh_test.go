@@ -0,0 +1,56 @@
+package parametric2d
+
+import (
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec3"
+)
+
+func TestNewMesh(t *testing.T) {
+	tris := []Triangle3D{
+		{vec3.T{0, 0, 0}, vec3.T{1, 0, 0}, vec3.T{0, 1, 0}},
+		{vec3.T{1, 0, 0}, vec3.T{1, 1, 0}, vec3.T{0, 1, 0}},
+		// Nearly coincident with the first triangle's vertices.
+		{vec3.T{1e-9, 0, 0}, vec3.T{0, 0, 1}, vec3.T{1, 1e-9, 0}},
+	}
+	tests := []struct {
+		name         string
+		epsilon      float64
+		wantVertices int
+	}{
+		{"exact", 0, 7},
+		{"welded", 1e-6, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMesh(tris, tt.epsilon)
+			if len(m.Vertices) != tt.wantVertices {
+				t.Errorf("NewMesh has %v vertices, want %v", len(m.Vertices), tt.wantVertices)
+			}
+			if len(m.Faces) != len(tris) {
+				t.Errorf("NewMesh has %v faces, want %v", len(m.Faces), len(tris))
+			}
+			// The shared edge of the first two triangles uses the same indices.
+			if m.Faces[0][1] != m.Faces[1][0] || m.Faces[0][2] != m.Faces[1][2] {
+				t.Errorf("NewMesh faces %v and %v do not share an edge", m.Faces[0], m.Faces[1])
+			}
+		})
+	}
+}
+
+func TestNewMesh_CollapsedTriangle(t *testing.T) {
+	tris := []Triangle3D{
+		{vec3.T{0, 0, 0}, vec3.T{1, 0, 0}, vec3.T{0, 1, 0}},
+		{vec3.T{0, 0, 0}, vec3.T{1e-9, 0, 0}, vec3.T{0, 1, 0}},
+	}
+	m := NewMesh(tris, 1e-6)
+	if len(m.Faces) != 1 {
+		t.Errorf("NewMesh has %v faces, want 1", len(m.Faces))
+	}
+	got := m.Triangles()
+	for i, v := range got[0] {
+		if v != tris[0][i] {
+			t.Errorf("Triangles()[0][%v] = %v, want %v", i, v, tris[0][i])
+		}
+	}
+}
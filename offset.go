@@ -0,0 +1,270 @@
+package parametric2d
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gmlewis/go3d/float64/bezier2"
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// DefaultOffsetTolerance is the maximum distance by which the offset
+// curves used to build bevels may deviate from the true offset curve.
+const DefaultOffsetTolerance = 1e-3
+
+// maxOffsetDepth limits the number of times a piece of an offset curve
+// is halved while fitting it.
+const maxOffsetDepth = 12
+
+// offsetSamples is the number of samples used to find where the offset
+// of a curve reverses direction.
+const offsetSamples = 32
+
+// Offset returns segments that trace the curve at distance 'd' along
+// the (unflipped) normal of the segment, which lies to the left of its
+// direction of travel.
+//
+// Lines and circular arcs are offset exactly. Other segments are
+// approximated by cubic Bezier curves to within the distance 'tol'. Where
+// 'd' exceeds the radius of curvature of the segment on its concave
+// side, the true offset curve has a cusp and runs backwards: the result
+// is split at each cusp and the backwards pieces are included as is, so
+// that they can be trimmed away along with other self-overlaps.
+//
+// An error is returned if the segment is degenerate or its normal is
+// undefined.
+func Offset(seg T, d, tol float64) ([]T, error) {
+	if isDegenerate(seg) {
+		return nil, ErrDegenerateSegment
+	}
+	for i := 0; i <= offsetSamples; i++ {
+		t := float64(i) / offsetSamples
+		if n := seg.NNormal(t); n.IsZero() {
+			return nil, fmt.Errorf("offset at t=%v: %w", t, ErrUndefinedNormal)
+		}
+	}
+	if d == 0 {
+		return []T{seg}, nil
+	}
+	switch s := seg.(type) {
+	case Line:
+		n := s.NNormal(0)
+		n.Scale(d)
+		return []T{NewLine(vec2.Add(&s.p0, &n), vec2.Add(&s.p1, &n))}, nil
+	case Arc:
+		if s.rx == s.ry {
+			// The left normal points toward the center of a counter-clockwise arc.
+			r := s.rx - d
+			if s.sweep < 0 {
+				r = s.rx + d
+			}
+			if r > 0 {
+				a := s
+				a.rx, a.ry = r, r
+				a.bbox = a.computeBBox()
+				return []T{a}, nil
+			}
+		}
+	}
+
+	// Split where the offset curve has a cusp, which is where 1 - d*k
+	// changes sign (k being the signed curvature).
+	g := func(t float64) float64 { return 1 - d*curvature(seg, t) }
+	ts := []float64{0}
+	prev := g(0)
+	for i := 1; i <= offsetSamples; i++ {
+		a, b := float64(i-1)/offsetSamples, float64(i)/offsetSamples
+		cur := g(b)
+		if (prev < 0) != (cur < 0) {
+			for j := 0; j < 50; j++ {
+				m := 0.5 * (a + b)
+				if (g(m) < 0) == (prev < 0) {
+					a = m
+				} else {
+					b = m
+				}
+			}
+			ts = append(ts, 0.5*(a+b))
+		}
+		prev = cur
+	}
+	ts = append(ts, 1)
+
+	var r []T
+	for i := 0; i < len(ts)-1; i++ {
+		r = fitOffset(r, seg, d, tol, ts[i], ts[i+1], 0)
+	}
+	return r, nil
+}
+
+// offsetPoint returns the point at distance 'd' along the normal of the
+// segment at 't'.
+func offsetPoint(seg T, d, t float64) vec2.T {
+	p := seg.At(t)
+	n := seg.NNormal(t)
+	return vec2.T{p[0] + d*n[0], p[1] + d*n[1]}
+}
+
+// offsetTangent returns the derivative of the offset curve at 't',
+// which is the segment's tangent scaled by 1 - d*k (k being the signed
+// curvature).
+func offsetTangent(seg T, d, t float64) vec2.T {
+	v := seg.Tangent(t)
+	v.Scale(1 - d*curvature(seg, t))
+	return v
+}
+
+// curvature returns the signed curvature of the segment at 't', which
+// is positive where the segment turns to the left.
+func curvature(seg T, t float64) float64 {
+	const h = 1e-6
+	t0, t1 := math.Max(0, t-h), math.Min(1, t+h)
+	v0, v1 := seg.Tangent(t0), seg.Tangent(t1)
+	dv := vec2.Sub(&v1, &v0)
+	dv.Scale(1 / (t1 - t0))
+	v := seg.Tangent(t)
+	l := v.Length()
+	if l == 0 {
+		return 0
+	}
+	return cross2(v, dv) / (l * l * l)
+}
+
+// fitOffset appends cubic curves that approximate the offset of the
+// segment between 't0' and 't1'. Each curve is the cubic Hermite
+// interpolant of the offset's endpoints and derivatives, and is halved
+// until it lies within 'tol' of the true offset.
+func fitOffset(r []T, seg T, d, tol, t0, t1 float64, depth int) []T {
+	p0, p3 := offsetPoint(seg, d, t0), offsetPoint(seg, d, t1)
+	v0, v3 := offsetTangent(seg, d, t0), offsetTangent(seg, d, t1)
+	// The derivative vanishes at a cusp, so follow the chord instead.
+	chord := vec2.Sub(&p3, &p0)
+	chord.Scale(1 / (t1 - t0))
+	if v0.Length() < 1e-9*chord.Length() {
+		v0 = chord
+	}
+	if v3.Length() < 1e-9*chord.Length() {
+		v3 = chord
+	}
+	k := (t1 - t0) / 3
+	b := bezier2.T{
+		P0: p0,
+		P1: vec2.T{p0[0] + k*v0[0], p0[1] + k*v0[1]},
+		P2: vec2.T{p3[0] - k*v3[0], p3[1] - k*v3[1]},
+		P3: p3,
+	}
+	c := Curve{spline: b, bbox: cubicBBox(&b)}
+	if depth >= maxOffsetDepth || offsetError(c, seg, d, t0, t1) <= tol {
+		if p0 == p3 {
+			return r
+		}
+		if b.P0 == b.P1 && b.P2 == b.P3 {
+			return append(r, NewLine(p0, p3))
+		}
+		return append(r, c)
+	}
+	m := 0.5 * (t0 + t1)
+	r = fitOffset(r, seg, d, tol, t0, m, depth+1)
+	return fitOffset(r, seg, d, tol, m, t1, depth+1)
+}
+
+// offsetError estimates how far the curve 'c' strays from the offset of
+// the segment between 't0' and 't1' by comparing sample points with the
+// offset points at the same position and with their distance from the
+// segment.
+func offsetError(c Curve, seg T, d, t0, t1 float64) float64 {
+	sub := seg.Sub(t0, t1)
+	var e float64
+	for _, u := range []float64{0.2, 0.4, 0.5, 0.6, 0.8} {
+		q := c.At(u)
+		_, dist := sub.ClosestPoint(q)
+		e1 := math.Abs(dist - math.Abs(d))
+		e2 := distance(q, offsetPoint(seg, d, t0+u*(t1-t0)))
+		e = math.Max(e, math.Min(e1, e2))
+	}
+	return e
+}
+
+// offsetRing returns the closed polygon (flattened using `maxDegrees`)
+// that traces the SubPath at distance 'd' along its normals, including
+// any cusps and self-overlaps, and ordered so that the side the normals
+// face is on its left.
+//
+// Where consecutive segments meet at a corner, the gap on the outside of
+// the corner is closed by a miter (as the segment Bevel methods do) and
+// on the inside the ring passes through the corner itself, which leaves
+// a small reversed loop that is trimmed along with the other
+// self-overlaps.
+func (s *SubPath) offsetRing(d, maxDegrees float64) ([]vec2.T, error) {
+	segs := s.Segments
+	if s.FlipNormals {
+		segs = make([]T, len(s.Segments))
+		for i, seg := range s.Segments {
+			segs[len(segs)-1-i] = seg.Reverse()
+		}
+	}
+	var ring []vec2.T
+	for i, seg := range segs {
+		pieces, err := Offset(seg, d, DefaultOffsetTolerance)
+		if err != nil {
+			if s.FlipNormals {
+				i = len(segs) - 1 - i
+			}
+			return nil, fmt.Errorf("segment %v: %w", i, err)
+		}
+		for _, piece := range pieces {
+			for _, t := range subdivisionTs(piece, maxDegrees)[1:] {
+				ring = append(ring, piece.At(t))
+			}
+		}
+		// Join the end of this segment's offset to the start of the next.
+		next := segs[(i+1)%len(segs)]
+		v := seg.At(1)
+		n0, n1 := seg.NNormal(1), next.NNormal(0)
+		if vec2.Dot(&n0, &n1) > 1-1e-12 {
+			continue // smooth
+		}
+		t0, t1 := seg.NTangent(1), next.NTangent(0)
+		if cross2(t0, t1)*d > 0 {
+			ring = append(ring, v) // inside of the corner
+		} else if bis := vec2.Add(&n0, &n1); !bis.IsZero() {
+			bis.Normalize()
+			angle := vec2.Angle(&n0, &n1)
+			l := d / math.Cos(0.5*angle)
+			ring = append(ring, vec2.T{v[0] + l*bis[0], v[1] + l*bis[1]})
+		}
+		ring = append(ring, offsetPoint(next, d, 0))
+	}
+	return ring, nil
+}
+
+// trimmedOffsetRing returns the offset ring of the SubPath with its
+// cusps and self-overlaps removed, which is the boundary of the points
+// that lie on the side of the ring that its normals face (taking into
+// account how many times the ring winds around each point). It returns
+// false if the result is not a single loop.
+func (s *SubPath) trimmedOffsetRing(d, maxDegrees float64) ([]vec2.T, bool, error) {
+	ring, err := s.offsetRing(d, maxDegrees)
+	if err != nil {
+		return nil, false, err
+	}
+	// Normals face the left of the ring. If the ring winds clockwise
+	// (around a hole), the region on its left is the unbounded one with
+	// winding number zero.
+	bias := 0
+	o := s.Orientation()
+	if s.FlipNormals {
+		o = -o
+	}
+	if o == Clockwise {
+		bias = 1
+	}
+	loops := resolvePolygon(ring, func(w int) bool { return w+bias > 0 })
+	if len(loops) != 1 {
+		if logger.enabled() {
+			logger.Debug("trimmed offset ring", "loops", len(loops))
+		}
+		return nil, false, nil
+	}
+	return loops[0], true, nil
+}
@@ -0,0 +1,182 @@
+package parametric2d
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/gmlewis/go-poly2tri"
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+func TestOffset(t *testing.T) {
+	tests := []struct {
+		name       string
+		seg        T
+		d          float64
+		wantPieces int
+	}{
+		{"line", NewLine(vec2.T{0, 0}, vec2.T{3, 4}), 1, 1},
+		{"circle inward", NewArc(vec2.T{1, 1}, 2, 2, 0, 10, 200), 0.5, 1},
+		{"circle outward", NewArc(vec2.T{1, 1}, 2, 2, 0, 10, -200), 0.5, 1},
+		{"curve", NewCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, -2}, vec2.T{3, 0}), 0.1, 0},
+		{"quadcurve", NewQuadCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0}), -0.3, 0},
+		{"ellipse", NewArc(vec2.T{0, 0}, 3, 1, 20, 0, 90), 0.2, 0},
+	}
+	for _, tt := range tests {
+		for _, tol := range []float64{1e-3, 1e-5} {
+			t.Run(fmt.Sprintf("%v/tol=%v", tt.name, tol), func(t *testing.T) {
+				got, err := Offset(tt.seg, tt.d, tol)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if tt.wantPieces > 0 && len(got) != tt.wantPieces {
+					t.Errorf("Offset = %v pieces, want %v", len(got), tt.wantPieces)
+				}
+				if p, want := got[0].At(0), offsetPoint(tt.seg, tt.d, 0); !vecNear(p, want, 1e-9) {
+					t.Errorf("Offset starts at %v, want %v", p, want)
+				}
+				if p, want := got[len(got)-1].At(1), offsetPoint(tt.seg, tt.d, 1); !vecNear(p, want, 1e-9) {
+					t.Errorf("Offset ends at %v, want %v", p, want)
+				}
+				for i, piece := range got {
+					for j := 0; j <= 10; j++ {
+						p := piece.At(float64(j) / 10)
+						_, dist := tt.seg.ClosestPoint(p)
+						if math.Abs(dist-math.Abs(tt.d)) > tol {
+							t.Errorf("piece #%v At(%v) = %v is %v from the segment, want %v", i, float64(j)/10, p, dist, math.Abs(tt.d))
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestOffset_Cusps(t *testing.T) {
+	// The radius of curvature at the top of the arch is 1.5, so offsetting
+	// it by 2 to its concave (right) side produces two cusps.
+	arch := NewCurve(vec2.T{0, 0}, vec2.T{0, 1}, vec2.T{2, 1}, vec2.T{2, 0})
+	got, err := Offset(arch, -2, DefaultOffsetTolerance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) < 3 {
+		t.Fatalf("Offset = %v pieces, want at least 3", len(got))
+	}
+	// The middle piece runs backwards.
+	var backwards bool
+	for _, piece := range got {
+		if v := piece.Tangent(0.5); v[0] < 0 {
+			backwards = true
+		}
+	}
+	if !backwards {
+		t.Errorf("Offset has no piece that runs backwards")
+	}
+}
+
+func TestOffset_UndefinedNormal(t *testing.T) {
+	// The curve itself has a cusp, where its normal is undefined.
+	v := NewCurve(vec2.T{0, 0}, vec2.T{2, 1}, vec2.T{0, 1}, vec2.T{2, 0})
+	if _, err := Offset(v, 0.1, DefaultOffsetTolerance); !errors.Is(err, ErrUndefinedNormal) {
+		t.Errorf("Offset = %v, want ErrUndefinedNormal", err)
+	}
+}
+
+// pointsArea returns the signed area of the polygon.
+func pointsArea(pts poly2tri.PointArray) float64 {
+	poly := make([]vec2.T, len(pts))
+	for i, p := range pts {
+		poly[i] = vec2.T{p.X, p.Y}
+	}
+	return polygonArea(poly)
+}
+
+func TestSubPathBevel_Offset(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        string
+		wantArea []float64 // absolute area enclosed by BevelPts of each subpath
+	}{
+		{"square", "M0 0h10v10h-10z", []float64{64}},
+		{"clockwise square", "M0 0v10h10v-10z", []float64{64}},
+		{"square with hole", "M0 0h10v10h-10z M3 3h4v4h-4z", []float64{64, 36}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustParseSVGPath(t, tt.d)
+			p.AutoFlipNormals()
+			for i, sp := range p.SubPaths {
+				if _, err := sp.Bevel(0, 1, 45, 10); err != nil {
+					t.Fatal(err)
+				}
+				if got := math.Abs(pointsArea(sp.BevelPts)); math.Abs(got-tt.wantArea[i]) > 1e-9 {
+					t.Errorf("subpath #%v BevelPts area = %v, want %v", i, got, tt.wantArea[i])
+				}
+			}
+			q := mustParseSVGPath(t, tt.d)
+			q.AutoFlipNormals()
+			tris, err := q.Bevel(0, 1, 45, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The bevel and its cap cover the whole solid.
+			want := 0.0
+			for _, sp := range p.SubPaths {
+				if sp.IsOuter {
+					want += math.Abs(sp.SignedArea())
+				} else {
+					want -= math.Abs(sp.SignedArea())
+				}
+			}
+			if got := projectedArea(tris); math.Abs(got-want) > 1e-9 {
+				t.Errorf("projected area = %v, want %v", got, want)
+			}
+			for i, tri := range tris {
+				if n := tri.Normal(); n[2] < -1e-12 {
+					t.Errorf("triangle #%v %v faces down: %v", i, tri, n)
+				}
+			}
+		})
+	}
+}
+
+func TestSubPathBevel_TightConcave(t *testing.T) {
+	// A rectangle whose top edge dips down in a tight curve.
+	sp := &SubPath{Segments: []T{
+		NewLine(vec2.T{0, 0}, vec2.T{4, 0}),
+		NewLine(vec2.T{4, 0}, vec2.T{4, 3}),
+		NewLine(vec2.T{4, 3}, vec2.T{3, 3}),
+		NewCurve(vec2.T{3, 3}, vec2.T{3, 1.5}, vec2.T{1, 1.5}, vec2.T{1, 3}),
+		NewLine(vec2.T{1, 3}, vec2.T{0, 3}),
+		NewLine(vec2.T{0, 3}, vec2.T{0, 0}),
+	}}
+	sp.AutoFlipNormals()
+	if _, ok, err := sp.trimmedOffsetRing(0.4, 5); !ok || err != nil {
+		t.Fatalf("trimmedOffsetRing = %v, %v, want a single loop", ok, err)
+	}
+	tris, err := sp.Bevel(0, 0.4, 45, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tris) == 0 || len(sp.BevelPts) == 0 {
+		t.Fatalf("Bevel = %v triangles and %v bevel points", len(tris), len(sp.BevelPts))
+	}
+	top := &SubPath{}
+	for i, p := range sp.BevelPts {
+		q := sp.BevelPts[(i+1)%len(sp.BevelPts)]
+		top.Segments = append(top.Segments, NewLine(vec2.T{p.X, p.Y}, vec2.T{q.X, q.Y}))
+	}
+	if xs := top.SelfIntersections(); len(xs) != 0 {
+		t.Errorf("the top of the bevel intersects itself at %v", xs)
+	}
+	for _, tri := range tris {
+		for _, p := range tri {
+			if math.IsNaN(p[0]) || math.IsNaN(p[1]) || math.IsNaN(p[2]) {
+				t.Fatalf("Bevel produced NaN vertex: %v", tri)
+			}
+		}
+	}
+}
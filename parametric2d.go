@@ -1,14 +1,24 @@
-// Package parametric2d defines 2D line and cubic curve segments that can be
+// Package parametric2d defines 2D line, arc, quadratic and cubic curve segments that can be
 // interpolated.  It also calculates tangents, normals, and offsets
 // to the segments in order to create 3D bevels.
 package parametric2d
 
 import (
+	"errors"
+
 	"github.com/gmlewis/go-poly2tri"
 	"github.com/gmlewis/go3d/float64/vec2"
 	"github.com/gmlewis/go3d/float64/vec3"
 )
 
+var (
+	// ErrDegenerateSegment is returned when a segment collapses to a single point.
+	ErrDegenerateSegment = errors.New("parametric2d: degenerate segment")
+	// ErrUndefinedNormal is returned when a segment has a zero-length
+	// tangent (such as at a cusp) where a normal is required.
+	ErrUndefinedNormal = errors.New("parametric2d: undefined normal")
+)
+
 // T represents a parametric 2D segment.
 type T interface {
 	// BBox returns the bounds of the segment.
@@ -27,7 +37,8 @@ type T interface {
 	// It subdivides the segment into as many vertical slices (making 2 triangles out of each slice)
 	// such that the maximum angle between adjacent angles is 'maxDegrees'.
 	// flipNormals determines if the normals are flipped from their default orientation.
-	Wall(height, maxDegrees float64, flipNormals bool) ([]Triangle3D, poly2tri.PointArray)
+	// An error is returned if the segment is degenerate.
+	Wall(height, maxDegrees float64, flipNormals bool) ([]Triangle3D, poly2tri.PointArray, error)
 	// Bevel returns a triangularized angled extrusion of the 2D segment starting at the given height.
 	// It subdivides the segment in the same manner as Wall().
 	// 'offset' specifies the horizontal distance to offset the original segment.
@@ -35,14 +46,43 @@ type T interface {
 	// flipNormals determines if the normals are flipped from their default orientation.
 	// prevNN is the previous segment's normalized normal at its t=1 endpoint.
 	// nextNN is the next segment's normalized normal at its t=0 endpoint..
-	Bevel(height, offset, deg, maxDegrees float64, flipNormals bool, prevNN, nextNN *vec2.T) ([]Triangle3D, poly2tri.PointArray)
+	// An error is returned if the segment is degenerate or its normal is undefined.
+	Bevel(height, offset, deg, maxDegrees float64, flipNormals bool, prevNN, nextNN *vec2.T) ([]Triangle3D, poly2tri.PointArray, error)
 	// IsLine returns true if this segment is a simple line segment
 	IsLine() bool
+	// Length returns the arc length of the segment, measured to within
+	// DefaultLengthTolerance (see ArcLength for other tolerances).
+	Length() float64
+	// AtLength returns the parametric 't' value at which the arc length
+	// measured from t=0 equals 's' (0 <= s <= Length()).
+	AtLength(s float64) float64
+	// Reverse returns a copy of the segment traversed in the opposite direction.
+	Reverse() T
+	// Split divides the segment at the parametric position 't' and returns
+	// the segments before and after it.
+	Split(t float64) (T, T)
+	// Sub returns the part of the segment between the parametric positions
+	// 't0' and 't1', which runs in the opposite direction if t1 < t0.
+	Sub(t0, t1 float64) T
+	// Transform returns a copy of the segment transformed by 'm'.
+	Transform(m Affine) T
+	// ClosestPoint returns the parametric 't' value of the point on the
+	// segment nearest to 'p' and its distance from 'p'.
+	ClosestPoint(p vec2.T) (t, dist float64)
 }
 
 // Triangle3D represents a 3D triangle.
 type Triangle3D []vec3.T
 
+// Normal returns the unit normal of the triangle according to the
+// right-hand rule, or the zero vector for a degenerate triangle.
+func (t Triangle3D) Normal() vec3.T {
+	a := vec3.Sub(&t[1], &t[0])
+	b := vec3.Sub(&t[2], &t[0])
+	n := vec3.Cross(&a, &b)
+	return *n.Normalize()
+}
+
 // TriangleWriter writes a triangle to some output.
 type TriangleWriter interface {
 	WriteTriangle3D(t Triangle3D) error
@@ -2,6 +2,7 @@ package parametric2d
 
 import (
 	"fmt"
+	"math"
 	"sort"
 
 	"github.com/gmlewis/go-poly2tri"
@@ -12,6 +13,12 @@ import (
 // Path represents a 2D collection of SubPaths.
 type Path struct {
 	SubPaths []*SubPath
+	// FillRule determines which regions enclosed by the SubPaths are solid
+	// when the Path is extruded.
+	FillRule FillRule
+	// OnSelfIntersection determines how self-intersecting SubPaths are
+	// handled when the Path is extruded.
+	OnSelfIntersection SelfIntersectionPolicy
 }
 
 // BBox returns the minimum bounding box of the Path.
@@ -27,74 +34,302 @@ func (p *Path) BBox() vec2.Rect {
 	return bbox
 }
 
+// Length returns the total arc length of all SubPaths in the Path.
+func (p *Path) Length() float64 {
+	var total float64
+	for _, sp := range p.SubPaths {
+		total += sp.Length()
+	}
+	return total
+}
+
+// Transform returns a copy of the Path transformed by 'm'.
+func (p *Path) Transform(m Affine) *Path {
+	r := &Path{SubPaths: make([]*SubPath, len(p.SubPaths)), FillRule: p.FillRule, OnSelfIntersection: p.OnSelfIntersection}
+	for i, sp := range p.SubPaths {
+		r.SubPaths[i] = sp.Transform(m)
+	}
+	return r
+}
+
+// ClosestPoint returns the indices of the SubPath and segment and the
+// parametric 't' value of the point on the Path nearest to 'p', along with
+// its distance from 'p'. SubPaths and segments whose bounding boxes are
+// farther away than the best point found so far are skipped. The indices
+// are -1 if the Path has no segments.
+func (p *Path) ClosestPoint(pt vec2.T) (int, int, float64, float64) {
+	bboxes := make([]vec2.Rect, len(p.SubPaths))
+	for i, sp := range p.SubPaths {
+		bboxes[i] = sp.BBox()
+	}
+	bestSP, bestSeg, bestT, best := -1, -1, 0.0, math.Inf(1)
+	for _, i := range byBBoxDistance(bboxes, pt) {
+		if bboxDistance(bboxes[i], pt) >= best {
+			break
+		}
+		if seg, t, d := p.SubPaths[i].ClosestPoint(pt); d < best {
+			bestSP, bestSeg, bestT, best = i, seg, t, d
+		}
+	}
+	return bestSP, bestSeg, bestT, best
+}
+
+// subPathGroup is an outer SubPath along with the holes directly inside it.
+type subPathGroup struct {
+	outer *SubPath
+	holes []*SubPath
+}
+
+// nest builds a containment tree of the SubPaths by testing whether each
+// one lies inside each of the others (flattened using `maxDegrees`).
+// SubPaths at an even nesting depth are outers (and are marked IsOuter)
+// and those at an odd depth are holes in their immediate parent, so
+// islands inside holes are supported to any depth.
+func (p *Path) nest(maxDegrees float64) []*subPathGroup {
+	n := len(p.SubPaths)
+	polys := make([][]vec2.T, n)
+	bboxes := make([]vec2.Rect, n)
+	for i, sp := range p.SubPaths {
+		polys[i] = sp.Flatten(maxDegrees)
+		bboxes[i] = polygonBBox(polys[i])
+	}
+	depth := make([]int, n)
+	parent := make([]int, n)
+	for i := range p.SubPaths {
+		parent[i] = -1
+		if len(polys[i]) == 0 {
+			continue
+		}
+		pt := samplePoint(polys[i])
+		for j := range p.SubPaths {
+			if i == j || len(polys[j]) == 0 || !bboxes[j].Contains(&bboxes[i]) {
+				continue
+			}
+			if pointInPolygon(pt, polys[j]) {
+				depth[i]++
+				// The immediate parent is the smallest container.
+				if parent[i] < 0 || bboxes[j].Area() < bboxes[parent[i]].Area() {
+					parent[i] = j
+				}
+			}
+		}
+	}
+
+	groups := make([]*subPathGroup, n)
+	var r []*subPathGroup
+	for i, sp := range p.SubPaths {
+		sp.IsOuter = depth[i]%2 == 0
+		if sp.IsOuter {
+			groups[i] = &subPathGroup{outer: sp}
+			r = append(r, groups[i])
+		}
+	}
+	for i, sp := range p.SubPaths {
+		if !sp.IsOuter && parent[i] >= 0 && groups[parent[i]] != nil {
+			groups[parent[i]].holes = append(groups[parent[i]].holes, sp)
+		}
+	}
+	return r
+}
+
 // Wall extrudes a path into a 3D wall. `maxDegrees` determines the smoothness
-// of the wall along the path.
-func (p *Path) Wall(height, maxDegrees float64) []Triangle3D {
+// of the wall along the path. Self-intersecting subpaths are first handled
+// according to the Path's OnSelfIntersection policy, and then overlapping
+// subpaths are resolved into the solid regions selected by its FillRule.
+// Only the SubPaths that bound those regions unchanged receive FloorPts;
+// the ones that have to be rebuilt are left as they were (see SolidRegions).
+func (p *Path) Wall(height, maxDegrees float64) ([]Triangle3D, error) {
+	return p.walls(maxDegrees, func(sp *SubPath) ([]Triangle3D, error) {
+		return sp.Wall(height, maxDegrees)
+	})
+}
+
+// DraftWall extrudes a path into a 3D wall that rises 'height' from its base
+// at 'z', leaning in toward the solid by 'draft' degrees from vertical (or
+// out, if 'draft' is negative), as described for SubPath.DraftWall. As with
+// Wall, the solid regions are determined by the Path's FillRule and the
+// floor is placed at the base.
+func (p *Path) DraftWall(z, height, draft, maxDegrees float64) ([]Triangle3D, error) {
+	return p.walls(maxDegrees, func(sp *SubPath) ([]Triangle3D, error) {
+		return sp.DraftWall(z, height, draft, maxDegrees)
+	})
+}
+
+// walls builds the wall of each SubPath of the path's solid regions and
+// triangulates the floors that they leave in FloorPts.
+func (p *Path) walls(maxDegrees float64, wall func(sp *SubPath) ([]Triangle3D, error)) ([]Triangle3D, error) {
 	if len(p.SubPaths) == 0 {
-		return []Triangle3D{}
+		return []Triangle3D{}, nil
+	}
+	p, err := p.prepare(maxDegrees)
+	if err != nil {
+		return nil, err
 	}
-	bbox := p.SubPaths[0].BBox()
 	r := make([]Triangle3D, 0, 100)
-	var sc *poly2tri.SweepContext
 	for i, sp := range p.SubPaths {
-		w := sp.Wall(height, maxDegrees)
+		w, err := wall(sp)
+		if err != nil {
+			return nil, fmt.Errorf("subpath %v: %w", i, err)
+		}
 		r = append(r, w...)
-		if i == 0 {
-			fmt.Printf("Initializing %v Floor points, #p.SubPaths=%v\n", len(sp.FloorPts), len(p.SubPaths))
-			sc = poly2tri.New(sp.FloorPts)
-		} else {
-			subBBox := sp.BBox()
-			fmt.Printf("Checking if floor bbox %v contains subBBox %v...\n", bbox, subBBox)
-			if bbox.Contains(&subBBox) {
-				fmt.Printf("Adding %v Floor hole points\n", len(sp.FloorPts))
-				sc.AddHole(sp.FloorPts)
-			} else {
-				fmt.Printf("SubPath not contained by parent... Adding %v new Floor points\n", len(sp.FloorPts))
-				r = Triangulate(sc.Triangulate(), r, p.SubPaths[0].FloorZ)
-				sc = poly2tri.New(sp.FloorPts)
+	}
+	for _, g := range p.nest(maxDegrees) {
+		if logger.enabled() {
+			logger.Debug("initializing floor points", "points", len(g.outer.FloorPts), "holes", len(g.holes))
+		}
+		sc := poly2tri.New(g.outer.FloorPts)
+		for _, hole := range g.holes {
+			if logger.enabled() {
+				logger.Debug("adding floor hole points", "points", len(hole.FloorPts))
 			}
+			sc.AddHole(hole.FloorPts)
 		}
+		r = Triangulate(sc.Triangulate(), r, g.outer.FloorZ)
 	}
-	r = Triangulate(sc.Triangulate(), r, p.SubPaths[0].FloorZ)
-	return r
+	return r, nil
+}
+
+// Bevel returns a 3D beveled object based on the provided path. As with Wall,
+// the solid regions are determined by the Path's FillRule. Where the offset
+// closes up narrow parts of a solid region (or its holes), the region is
+// bevelled along its straight skeleton, as described for SubPath.Bevel.
+func (p *Path) Bevel(height, offset, deg, maxDegrees float64) ([]Triangle3D, error) {
+	return p.ProfileBevel(height, LinearProfile(offset, deg), maxDegrees)
 }
 
-// Bevel returns a 3D beveled object based on the provided path.
-func (p *Path) Bevel(height, offset, deg, maxDegrees float64) []Triangle3D {
+// ProfileBevel returns a 3D beveled object whose cross section follows the
+// profile, swept around the edges of the path's solid regions from
+// 'height'. The profile's curves are subdivided using `maxDegrees` and each
+// point of the profile adds a ring of triangles. As with Bevel, regions
+// whose narrow parts are closed up by the profile's inset are bevelled
+// along their straight skeleton, and the top of the bevel is capped.
+// As with Wall, BevelPts are only recorded in the SubPaths that are not
+// rebuilt by SolidRegions.
+func (p *Path) ProfileBevel(height float64, profile *BevelProfile, maxDegrees float64) ([]Triangle3D, error) {
 	if len(p.SubPaths) == 0 {
-		return []Triangle3D{}
+		return []Triangle3D{}, nil
 	}
-	bbox := p.SubPaths[0].BBox()
-	r := make([]Triangle3D, 0, 100)
-	var sc *poly2tri.SweepContext
+	pts, err := profile.points(maxDegrees)
+	if err != nil {
+		return nil, err
+	}
+	p, err = p.prepare(maxDegrees)
+	if err != nil {
+		return nil, err
+	}
+	topZ := height + pts[len(pts)-1][1]
+	index := make(map[*SubPath]int, len(p.SubPaths))
 	for i, sp := range p.SubPaths {
-		w := sp.Bevel(height, offset, deg, maxDegrees)
-		r = append(r, w...)
-		if i == 0 {
-			fmt.Printf("Initializing %v Bevel points, #p.SubPaths=%v\n", len(sp.BevelPts), len(p.SubPaths))
-			if len(p.SubPaths) == 1 {
-				fmt.Printf("\nvar in = PointArray{\n")
-				for _, t := range sp.BevelPts {
-					fmt.Printf("  NewPoint(%v, %v),\n", t.X, t.Y)
+		index[sp] = i
+		sp.BevelZ = topZ
+	}
+	r := make([]Triangle3D, 0, 100)
+	for _, g := range p.nest(maxDegrees) {
+		var tops [][]vec2.T
+		if r, tops, err = g.profileBevel(r, pts, height, maxDegrees, index); err != nil {
+			return nil, err
+		}
+		for i, sp := range append([]*SubPath{g.outer}, g.holes...) {
+			sp.BevelPts = nil
+			if tops != nil {
+				sp.addBevelPts(tops[i])
+			}
+		}
+	}
+	return r, nil
+}
+
+// profileBevel appends the bevel of the group whose cross section follows
+// the profile points from 'height', along with its top cap. If the bevel
+// follows the offsets of the group's SubPaths, their tops (outer first)
+// are also returned. 'index' maps each SubPath to its position in the Path
+// for error messages.
+func (g *subPathGroup) profileBevel(r []Triangle3D, pts []vec2.T, height, maxDegrees float64, index map[*SubPath]int) ([]Triangle3D, [][]vec2.T, error) {
+	topZ := height + pts[len(pts)-1][1]
+	r, tops, offsets, err := g.bevelRings(r, pts, height, maxDegrees, index)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !offsets {
+		return capRings(r, tops, topZ, maxDegrees), nil, nil
+	}
+	return capGroup(r, tops, topZ), tops, nil
+}
+
+// bevelRings appends the bevel of the group whose cross section follows
+// the profile points from 'height', without its top cap, and returns the
+// rings at its top. 'offsets' reports whether the bevel follows the
+// offsets of the group's SubPaths, in which case the rings are their tops
+// (outer first); otherwise the group is bevelled along its straight
+// skeleton and the rings are those left at the top of the roof. 'index'
+// maps each SubPath to its position in the Path for error messages, or is
+// nil if the group is a lone SubPath.
+func (g *subPathGroup) bevelRings(r []Triangle3D, pts []vec2.T, height, maxDegrees float64, index map[*SubPath]int) (_ []Triangle3D, tops [][]vec2.T, offsets bool, err error) {
+	members := append([]*SubPath{g.outer}, g.holes...)
+	tops = make([][]vec2.T, len(members))
+	var strips []Triangle3D
+	fits := true
+	for i, sp := range members {
+		ring := sp.solidRing(maxDegrees)
+		for k := 1; k < len(pts) && fits; k++ {
+			next := ring
+			if pts[k][0] > pts[k-1][0] {
+				top, ok, err := sp.trimmedOffsetRing(pts[k][0], maxDegrees)
+				if err != nil {
+					if index != nil {
+						err = fmt.Errorf("subpath %v: %w", index[sp], err)
+					}
+					return nil, nil, false, err
 				}
-				fmt.Printf("}\n\n")
+				next, fits = top, ok
 			}
-			sc = poly2tri.New(sp.BevelPts)
-		} else {
-			subBBox := sp.BBox()
-			fmt.Printf("Checking if bevel bbox %v contains subBBox %v...\n", bbox, subBBox)
-			if bbox.Contains(&subBBox) {
-				fmt.Printf("Adding %v Bevel hole points\n", len(sp.BevelPts))
-				sc.AddHole(sp.BevelPts)
-			} else {
-				fmt.Printf("SubPath not contained by parent... Adding %v new Bevel points\n", len(sp.BevelPts))
-				r = Triangulate(sc.Triangulate(), r, p.SubPaths[0].BevelZ)
-				sc = poly2tri.New(sp.BevelPts)
+			if fits {
+				var w []Triangle3D
+				w, fits = bevelStrip(ring, next, height+pts[k-1][1], height+pts[k][1])
+				strips = append(strips, w...)
 			}
+			ring = next
 		}
+		tops[i] = ring
 	}
-	r = Triangulate(sc.Triangulate(), r, p.SubPaths[0].BevelZ)
-	return r
+	// The rings of the outer only shrink and those of the holes only
+	// grow as the inset increases, so if the tops do not run into each
+	// other, neither do any of the rings below them.
+	if fits && (len(tops) == 1 || nestedRings(tops)) {
+		return append(r, strips...), tops, true, nil
+	}
+	// The inset closes up narrow parts of the region, or the offsets of
+	// the outer and its holes run into each other.
+	if logger.enabled() {
+		logger.Debug("bevelling along the straight skeleton", "holes", len(g.holes))
+	}
+	rings := make([][]vec2.T, len(members))
+	for i, sp := range members {
+		rings[i] = sp.solidRing(maxDegrees)
+	}
+	w, tops, err := profileRoof(rings, pts, height)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return append(r, w...), tops, false, nil
+}
+
+// prepare applies the Path's OnSelfIntersection policy and FillRule and
+// returns the Path to be extruded.
+func (p *Path) prepare(maxDegrees float64) (*Path, error) {
+	switch p.OnSelfIntersection {
+	case RejectSelfIntersections:
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+	case SplitSelfIntersections:
+		q := &Path{FillRule: p.FillRule, OnSelfIntersection: p.OnSelfIntersection}
+		for _, sp := range p.SubPaths {
+			q.SubPaths = append(q.SubPaths, sp.SplitLoops()...)
+		}
+		p = q
+	}
+	return p.SolidRegions(maxDegrees), nil
 }
 
 // Triangulate converts 2D points to 3D triangles and appends them to a slice.
@@ -109,9 +344,11 @@ func Triangulate(m poly2tri.TriArray, r []Triangle3D, z float64) []Triangle3D {
 	return r
 }
 
-// AutoFlipNormals analyzes the first subpath to determine if its normals
-// are currently facing the correct direction (outward), and if not,
-// sets the 'FlipNormals' flag on each segment of each subpath.
+// AutoFlipNormals determines the nesting and orientation of each subpath
+// and sets its 'FlipNormals' flag so that the normals of outer subpaths
+// face their interior (the solid) and the normals of holes face away
+// from their interior (again, toward the solid), regardless of the
+// direction in which each subpath was drawn.
 func (p *Path) AutoFlipNormals() {
 	if len(p.SubPaths) == 0 {
 		return
@@ -119,11 +356,11 @@ func (p *Path) AutoFlipNormals() {
 	// First, sort the subpaths by bounding box square area... Largest first
 	sort.Sort(byBBoxArea(p.SubPaths))
 
-	p.SubPaths[0].IsOuter = true
-	p.SubPaths[0].AutoFlipNormals()
-	if p.SubPaths[0].FlipNormals {
-		for _, sp := range p.SubPaths[1:] {
-			sp.FlipNormals = true
+	p.nest(orientationMaxDegrees)
+	for _, sp := range p.SubPaths {
+		sp.AutoFlipNormals()
+		if !sp.IsOuter {
+			sp.FlipNormals = !sp.FlipNormals
 		}
 	}
 }
@@ -0,0 +1,106 @@
+package parametric2d
+
+import (
+	"math"
+	"testing"
+)
+
+// projectedArea returns the total area of the triangles projected onto
+// the XY plane, which for the output of Path.Wall is the floor area.
+func projectedArea(tris []Triangle3D) float64 {
+	var area float64
+	for _, t := range tris {
+		area += 0.5 * math.Abs((t[1][0]-t[0][0])*(t[2][1]-t[0][1])-(t[2][0]-t[0][0])*(t[1][1]-t[0][1]))
+	}
+	return area
+}
+
+func mustParseSVGPath(t *testing.T, d string) *Path {
+	t.Helper()
+	p, err := ParseSVGPath(d)
+	if err != nil {
+		t.Fatalf("ParseSVGPath(%q): %v", d, err)
+	}
+	return p
+}
+
+func TestPathWall_Nesting(t *testing.T) {
+	tests := []struct {
+		name      string
+		d         string
+		wantArea  float64
+		wantOuter []bool
+	}{
+		{
+			name:      "square",
+			d:         "M0 0h10v10h-10z",
+			wantArea:  100,
+			wantOuter: []bool{true},
+		},
+		{
+			name:      "square with hole",
+			d:         "M0 0h10v10h-10z M2 2h6v6h-6z",
+			wantArea:  64,
+			wantOuter: []bool{true, false},
+		},
+		{
+			name:      "island inside hole",
+			d:         "M0 0h10v10h-10z M2 2h6v6h-6z M4 4h2v2h-2z",
+			wantArea:  68,
+			wantOuter: []bool{true, false, true},
+		},
+		{
+			name:      "nested rings listed inside out",
+			d:         "M4 4h2v2h-2z M2 2h6v6h-6z M0 0h10v10h-10z M-2 -2h14v14h-14z",
+			wantArea:  196 - 100 + 36 - 4,
+			wantOuter: []bool{false, true, false, true},
+		},
+		{
+			// The square's bbox lies within the L's bbox, but the square is
+			// in the notch of the L so it is a separate solid, not a hole.
+			name:      "square in notch of L",
+			d:         "M0 0h10v4h-6v6h-4z M6 6h2v2h-2z",
+			wantArea:  64 + 4,
+			wantOuter: []bool{true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustParseSVGPath(t, tt.d)
+			tris, err := p.Wall(1, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := projectedArea(tris); math.Abs(got-tt.wantArea) > 1e-9 {
+				t.Errorf("floor area = %v, want %v", got, tt.wantArea)
+			}
+			for i, sp := range p.SubPaths {
+				if sp.IsOuter != tt.wantOuter[i] {
+					t.Errorf("subpath #%v IsOuter = %v, want %v", i, sp.IsOuter, tt.wantOuter[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPathWall_Repeated(t *testing.T) {
+	p := mustParseSVGPath(t, "M0 0h10v10h-10z M3 3v4h4v-4z")
+	calls := []struct {
+		name string
+		wall func() ([]Triangle3D, error)
+	}{
+		{"Wall", func() ([]Triangle3D, error) { return p.Wall(1, 10) }},
+		{"Wall again", func() ([]Triangle3D, error) { return p.Wall(1, 10) }},
+		{"DraftWall", func() ([]Triangle3D, error) { return p.DraftWall(0, 1, 0, 10) }},
+		{"Wall after DraftWall", func() ([]Triangle3D, error) { return p.Wall(1, 10) }},
+	}
+	for _, c := range calls {
+		tris, err := c.wall()
+		if err != nil {
+			t.Fatalf("%v: %v", c.name, err)
+		}
+		if got := projectedArea(tris); math.Abs(got-84) > 1e-9 {
+			t.Errorf("%v floor area = %v, want 84", c.name, got)
+		}
+	}
+}
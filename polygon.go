@@ -0,0 +1,72 @@
+package parametric2d
+
+import (
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// pointInPolygon returns true if the point p lies inside the closed
+// polygon using the even-odd rule.
+func pointInPolygon(p vec2.T, poly []vec2.T) bool {
+	inside := false
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		if (a[1] > p[1]) != (b[1] > p[1]) {
+			x := a[0] + (p[1]-a[1])*(b[0]-a[0])/(b[1]-a[1])
+			if p[0] < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// polygonArea returns the signed area of the closed polygon, which is
+// positive when the polygon winds counter-clockwise.
+func polygonArea(poly []vec2.T) float64 {
+	var area float64
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		area += a[0]*b[1] - b[0]*a[1]
+	}
+	return 0.5 * area
+}
+
+// cross3 returns the z component of (b-a) x (p-a), which is positive
+// when p lies to the left of the directed line from a to b.
+func cross3(a, b, p vec2.T) float64 {
+	return (b[0]-a[0])*(p[1]-a[1]) - (p[0]-a[0])*(b[1]-a[1])
+}
+
+// cross2 returns the z component of a x b.
+func cross2(a, b vec2.T) float64 {
+	return a[0]*b[1] - a[1]*b[0]
+}
+
+// polygonBBox returns the bounding box of the polygon.
+func polygonBBox(poly []vec2.T) vec2.Rect {
+	if len(poly) == 0 {
+		return vec2.Rect{}
+	}
+	bbox := vec2.Rect{Min: poly[0], Max: poly[0]}
+	for _, p := range poly[1:] {
+		bbox.Min = vec2.Min(&bbox.Min, &p)
+		bbox.Max = vec2.Max(&bbox.Max, &p)
+	}
+	return bbox
+}
+
+// samplePoint returns a point on the boundary of the polygon (the
+// midpoint of its longest edge) that is used to test its containment
+// within other, non-crossing polygons.
+func samplePoint(poly []vec2.T) vec2.T {
+	best, bestLen := 0, -1.0
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		d := vec2.Sub(&b, &a)
+		if l := d.LengthSqr(); l > bestLen {
+			best, bestLen = i, l
+		}
+	}
+	a, b := poly[best], poly[(best+1)%len(poly)]
+	return vec2.Interpolate(&a, &b, 0.5)
+}
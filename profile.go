@@ -0,0 +1,118 @@
+package parametric2d
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// ErrInvalidProfile is returned when a BevelProfile does not start at its
+// foot, is not connected, or moves back out toward the edge of the solid.
+var ErrInvalidProfile = errors.New("parametric2d: invalid bevel profile")
+
+// BevelProfile describes the cross section of a bevel as a connected
+// series of segments. The x coordinate of each point of the profile is
+// its inset from the edge of the solid, and the y coordinate is its
+// height above the foot of the bevel, so the profile starts at (0, 0) and
+// its inset never decreases. Rising steps (where the inset stays the same)
+// become vertical walls, and level steps become flat terraces.
+type BevelProfile struct {
+	Segments []T
+}
+
+// NewBevelProfile returns a BevelProfile made of the segments.
+func NewBevelProfile(segments ...T) *BevelProfile {
+	return &BevelProfile{Segments: segments}
+}
+
+// LinearProfile returns the profile of a single chamfer that is 'offset'
+// wide and rises at 'deg' degrees, as made by Path.Bevel.
+func LinearProfile(offset, deg float64) *BevelProfile {
+	h := offset * math.Tan(deg*math.Pi/180.0)
+	return NewBevelProfile(NewLine(vec2.T{0, 0}, vec2.T{offset, h}))
+}
+
+// RoundProfile returns the profile of a convex quarter round (a bullnose)
+// of the given radius, which rises straight up from the edge of the solid
+// and curves over to meet the top.
+func RoundProfile(radius float64) *BevelProfile {
+	return NewBevelProfile(NewArc(vec2.T{radius, 0}, radius, radius, 0, 180, -90))
+}
+
+// CoveProfile returns the profile of a concave quarter round (a cove) of
+// the given radius, which starts out level and curves up to meet the top
+// straight on.
+func CoveProfile(radius float64) *BevelProfile {
+	return NewBevelProfile(NewArc(vec2.T{0, radius}, radius, radius, 0, -90, 90))
+}
+
+// OgeeProfile returns the profile of an ogee that is 'width' wide and
+// 'height' high: a cove over the first half that turns into a round over
+// the second half, so that the profile starts and ends level.
+func OgeeProfile(width, height float64) *BevelProfile {
+	rx, ry := 0.5*width, 0.5*height
+	return NewBevelProfile(
+		NewArc(vec2.T{0, ry}, rx, ry, 0, -90, 90),
+		NewArc(vec2.T{width, ry}, rx, ry, 0, 180, -90),
+	)
+}
+
+// StepProfile returns the profile of 'steps' square steps that together
+// are 'width' wide and 'height' high. Each step rises straight up and then
+// runs level toward the inside of the solid.
+func StepProfile(steps int, width, height float64) *BevelProfile {
+	p := &BevelProfile{}
+	if steps < 1 {
+		return p
+	}
+	run, rise := width/float64(steps), height/float64(steps)
+	var v vec2.T
+	for i := 0; i < steps; i++ {
+		up := vec2.T{v[0], v[1] + rise}
+		next := vec2.T{v[0] + run, up[1]}
+		p.Segments = append(p.Segments, NewLine(v, up), NewLine(up, next))
+		v = next
+	}
+	return p
+}
+
+// Size returns the inset and height of the end of the profile.
+func (b *BevelProfile) Size() (inset, height float64) {
+	if len(b.Segments) == 0 {
+		return 0, 0
+	}
+	v := b.Segments[len(b.Segments)-1].At(1)
+	return v[0], v[1]
+}
+
+// points returns the points of the profile, with its curved segments
+// subdivided using `maxDegrees` and repeated points removed. An error is
+// returned if the profile is not valid.
+func (b *BevelProfile) points(maxDegrees float64) ([]vec2.T, error) {
+	const eps = 1e-9
+	r := []vec2.T{{0, 0}}
+	for i, seg := range b.Segments {
+		if p := seg.At(0); distance(p, r[len(r)-1]) > eps {
+			if i == 0 {
+				return nil, fmt.Errorf("profile starts at %v: %w", p, ErrInvalidProfile)
+			}
+			return nil, fmt.Errorf("segment %v starts at %v, not %v: %w", i, p, r[len(r)-1], ErrInvalidProfile)
+		}
+		if isDegenerate(seg) {
+			continue
+		}
+		for _, t := range subdivisionTs(seg, maxDegrees)[1:] {
+			p := seg.At(t)
+			last := r[len(r)-1]
+			if p[0] < last[0]-eps {
+				return nil, fmt.Errorf("segment %v moves back out to %v: %w", i, p, ErrInvalidProfile)
+			}
+			if distance(p, last) > eps {
+				r = append(r, vec2.T{math.Max(p[0], last[0]), p[1]})
+			}
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,126 @@
+package parametric2d
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+func TestBevelProfile(t *testing.T) {
+	tests := []struct {
+		name          string
+		profile       *BevelProfile
+		inset, height float64
+		via           vec2.T // a point along the profile
+	}{
+		{"linear", LinearProfile(2, 45), 2, 2, vec2.T{2, 2}},
+		{"round", RoundProfile(1), 1, 1, vec2.T{1 - math.Sqrt(0.75), 0.5}},
+		{"cove", CoveProfile(1), 1, 1, vec2.T{0.5, 1 - math.Sqrt(0.75)}},
+		{"ogee", OgeeProfile(2, 1), 2, 1, vec2.T{1, 0.5}},
+		{"steps", StepProfile(3, 3, 1.5), 3, 1.5, vec2.T{1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inset, height := tt.profile.Size()
+			if math.Abs(inset-tt.inset) > 1e-9 || math.Abs(height-tt.height) > 1e-9 {
+				t.Errorf("Size = %v, %v, want %v, %v", inset, height, tt.inset, tt.height)
+			}
+			pts, err := tt.profile.points(10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !vecNear(pts[0], vec2.T{0, 0}, 1e-12) {
+				t.Errorf("points start at %v, want (0, 0)", pts[0])
+			}
+			found := false
+			for _, p := range pts {
+				if vecNear(p, tt.via, 1e-9) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("points = %v, want them to include %v", pts, tt.via)
+			}
+		})
+	}
+}
+
+func TestBevelProfile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile *BevelProfile
+	}{
+		{"off the foot", NewBevelProfile(NewLine(vec2.T{1, 0}, vec2.T{2, 1}))},
+		{"disconnected", NewBevelProfile(NewLine(vec2.T{0, 0}, vec2.T{1, 1}), NewLine(vec2.T{1, 2}, vec2.T{2, 3}))},
+		{"overhang", NewBevelProfile(NewLine(vec2.T{0, 0}, vec2.T{1, 1}), NewLine(vec2.T{1, 1}, vec2.T{0.5, 2}))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.profile.points(10); !errors.Is(err, ErrInvalidProfile) {
+				t.Errorf("points = %v, want ErrInvalidProfile", err)
+			}
+			p := mustParseSVGPath(t, "M0 0h10v10h-10z")
+			if _, err := p.ProfileBevel(0, tt.profile, 10); !errors.Is(err, ErrInvalidProfile) {
+				t.Errorf("ProfileBevel = %v, want ErrInvalidProfile", err)
+			}
+		})
+	}
+}
+
+func TestPathProfileBevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       string
+		profile *BevelProfile
+		wantTop float64 // area of the flat top, including the last step
+		wantZ   float64
+		tolZ    float64
+	}{
+		{"linear", "M0 0h10v10h-10z", LinearProfile(1, 45), 64, 1, 1e-9},
+		{"round", "M0 0h10v10h-10z", RoundProfile(2), 36, 2, 1e-9},
+		{"cove", "M0 0h10v10h-10z", CoveProfile(2), 36, 2, 1e-9},
+		{"ogee", "M0 0h10v10h-10z", OgeeProfile(2, 1), 36, 1, 1e-9},
+		{"steps", "M0 0h10v10h-10z", StepProfile(3, 3, 1.5), 36, 1.5, 1e-9},
+		{"round with hole", "M0 0h10v10h-10z M4 4v2h2v-2z", RoundProfile(1), 64 - 16, 1, 1e-9},
+		{"round ridge", "M0 0h10v2h-10z", RoundProfile(1.5), 0, math.Sqrt(2), 0.05},
+		{"steps ridge", "M0 0h10v2h-10z", StepProfile(2, 3, 1), 0, 0.5, 1e-9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustParseSVGPath(t, tt.d)
+			p.AutoFlipNormals()
+			want := 0.0
+			for _, sp := range p.SubPaths {
+				if sp.IsOuter {
+					want += math.Abs(sp.SignedArea())
+				} else {
+					want -= math.Abs(sp.SignedArea())
+				}
+			}
+			const height = 3
+			tris, err := p.ProfileBevel(height, tt.profile, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, h := tt.profile.Size()
+			if got := flatArea(tris, height+h); math.Abs(got-tt.wantTop) > 1e-9 {
+				t.Errorf("top area = %v, want %v", got, tt.wantTop)
+			}
+			if got := maxZ(tris); math.Abs(got-height-tt.wantZ) > tt.tolZ {
+				t.Errorf("height = %v, want %v", got, height+tt.wantZ)
+			}
+			// The bevel covers the solid once, except for the vertical
+			// walls of steps.
+			if got := projectedArea(tris); math.Abs(got-want) > 1e-9 {
+				t.Errorf("projected area = %v, want %v", got, want)
+			}
+			for i, tri := range tris {
+				if n := tri.Normal(); n[2] < -1e-9 {
+					t.Errorf("triangle #%v %v faces down: %v", i, tri, n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package parametric2d
+
+import (
+	"github.com/gmlewis/go-poly2tri"
+	"github.com/gmlewis/go3d/float64/qbezier2"
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// QuadCurve represents a 2D quadratic Bezier curve and implements interface T.
+type QuadCurve struct {
+	spline qbezier2.T
+	bbox   vec2.Rect
+}
+
+// NewQuadCurve returns a new 2D quadratic Bezier curve from three points.
+// The control point may coincide with an endpoint; see NTangent.
+func NewQuadCurve(p0, p1, p2 vec2.T) QuadCurve {
+	s := qbezier2.T{P0: p0, P1: p1, P2: p2}
+	return QuadCurve{spline: s, bbox: quadBBox(&s)}
+}
+
+// quadBBox returns the tight bounds of a quadratic Bezier spline by
+// evaluating it at its endpoints and at the root of its derivative
+// along each axis.
+func quadBBox(s *qbezier2.T) vec2.Rect {
+	ll := vec2.Min(&s.P0, &s.P2)
+	ur := vec2.Max(&s.P0, &s.P2)
+	for axis := 0; axis < 2; axis++ {
+		p0, p1, p2 := s.P0[axis], s.P1[axis], s.P2[axis]
+		// The derivative (divided by 2) is (p0-2*p1+p2)*t + (p1-p0).
+		for _, t := range rootsInUnitInterval(quadraticRoots(0, p0-2*p1+p2, p1-p0)) {
+			v := s.Point(t)
+			ll = vec2.Min(&ll, &v)
+			ur = vec2.Max(&ur, &v)
+		}
+	}
+	return vec2.Rect{Min: ll, Max: ur}
+}
+
+// BBox returns the minimum bounding box of the QuadCurve.
+func (s QuadCurve) BBox() vec2.Rect {
+	return s.bbox
+}
+
+// At returns the point on the QuadCurve at the given position (0 <= t <= 1).
+func (s QuadCurve) At(t float64) vec2.T {
+	return s.spline.Point(t)
+}
+
+// Tangent returns the tangent to the QuadCurve
+// at the given position (0 <= t <= 1).
+func (s QuadCurve) Tangent(t float64) vec2.T {
+	// qbezier2.Tangent panics on a zero tangent, so the derivative is
+	// evaluated here and a zero tangent is returned instead.
+	p := &s.spline
+	a := vec2.Sub(&p.P1, &p.P0)
+	b := vec2.Sub(&p.P2, &p.P1)
+	return vec2.T{
+		2*(1-t)*a[0] + 2*t*b[0],
+		2*(1-t)*a[1] + 2*t*b[1],
+	}
+}
+
+// NTangent returns the normalized tangent to the QuadCurve
+// at the given position (0 <= t <= 1). If the control point coincides
+// with the end of the curve at t=0 or t=1, the tangent vanishes there, and
+// the direction of the chord is returned instead.
+func (s QuadCurve) NTangent(t float64) vec2.T {
+	v := s.direction(t)
+	return *v.Normalize()
+}
+
+// direction returns the tangent at 't', or the chord at an end of the
+// curve where the tangent vanishes.
+func (s QuadCurve) direction(t float64) vec2.T {
+	v := s.Tangent(t)
+	if !v.IsZero() || (t != 0 && t != 1) {
+		return v
+	}
+	return vec2.Sub(&s.spline.P2, &s.spline.P0)
+}
+
+// Normal returns the normal to the QuadCurve
+// at the given position (0 <= t <= 1).
+func (s QuadCurve) Normal(t float64) vec2.T {
+	v := s.Tangent(t)
+	return *v.Rotate90DegLeft()
+}
+
+// NNormal returns the normalized normal to the QuadCurve
+// at the given position (0 <= t <= 1), which is defined at its ends as
+// for NTangent.
+func (s QuadCurve) NNormal(t float64) vec2.T {
+	v := s.direction(t)
+	v.Rotate90DegLeft()
+	return *v.Normalize()
+}
+
+// Subdivide returns the parametric 't' values along the curve
+// such that the tangent between two points never exceeds `maxDegrees`.
+func (s QuadCurve) Subdivide(maxDegrees float64) []float64 {
+	return subdivide(s, maxDegrees)
+}
+
+// Wall extrudes a quadratic curve into a 3D wall. `maxDegrees` determines
+// the smoothness of the wall along the curve.
+func (s QuadCurve) Wall(height, maxDegrees float64, flipNormals bool) ([]Triangle3D, poly2tri.PointArray, error) {
+	return wall(s, s.Subdivide(maxDegrees), height, flipNormals)
+}
+
+// Bevel returns a 3D beveled object based on the provided quadratic curve.
+func (s QuadCurve) Bevel(height, offset, deg, maxDegrees float64, flipNormals bool, prevNN, nextNN *vec2.T) ([]Triangle3D, poly2tri.PointArray, error) {
+	return bevel(s, s.Subdivide(maxDegrees), height, offset, deg, flipNormals, prevNN, nextNN)
+}
+
+// IsLine is false for type QuadCurve.
+func (s QuadCurve) IsLine() bool {
+	return false
+}
+
+// Reverse returns the QuadCurve traversed from its last point to its first.
+func (s QuadCurve) Reverse() T {
+	sp := qbezier2.T{P0: s.spline.P2, P1: s.spline.P1, P2: s.spline.P0}
+	return QuadCurve{spline: sp, bbox: s.bbox}
+}
+
+// Points returns the three control points of the QuadCurve.
+func (s QuadCurve) Points() (p0, p1, p2 vec2.T) {
+	return s.spline.P0, s.spline.P1, s.spline.P2
+}
+
+// Split divides the QuadCurve at position 't' using de Casteljau's algorithm.
+func (s QuadCurve) Split(t float64) (T, T) {
+	p := &s.spline
+	p01 := vec2.Interpolate(&p.P0, &p.P1, t)
+	p12 := vec2.Interpolate(&p.P1, &p.P2, t)
+	m := vec2.Interpolate(&p01, &p12, t)
+	a := qbezier2.T{P0: p.P0, P1: p01, P2: m}
+	b := qbezier2.T{P0: m, P1: p12, P2: p.P2}
+	return QuadCurve{spline: a, bbox: quadBBox(&a)}, QuadCurve{spline: b, bbox: quadBBox(&b)}
+}
+
+// Sub returns the part of the QuadCurve between positions 't0' and 't1'.
+func (s QuadCurve) Sub(t0, t1 float64) T {
+	if t1 < t0 {
+		return s.Sub(t1, t0).Reverse()
+	}
+	_, tail := s.Split(t0)
+	if t0 >= 1 {
+		return tail
+	}
+	head, _ := tail.Split((t1 - t0) / (1 - t0))
+	return head
+}
+
+// Transform returns the QuadCurve transformed by 'm'.
+func (s QuadCurve) Transform(m Affine) T {
+	sp := qbezier2.T{P0: m.Apply(s.spline.P0), P1: m.Apply(s.spline.P1), P2: m.Apply(s.spline.P2)}
+	return QuadCurve{spline: sp, bbox: quadBBox(&sp)}
+}
+
+// ClosestPoint returns the position along the QuadCurve nearest to 'p'
+// and its distance from 'p'.
+func (s QuadCurve) ClosestPoint(p vec2.T) (float64, float64) {
+	return closestPointOnBezier(s, []vec2.T{s.spline.P0, s.spline.P1, s.spline.P2}, p)
+}
+
+// Length returns the arc length of the QuadCurve.
+func (s QuadCurve) Length() float64 {
+	return arcLength(s, 0, 1, DefaultLengthTolerance)
+}
+
+// AtLength returns the position (0 <= t <= 1) at the given arc length
+// along the QuadCurve.
+func (s QuadCurve) AtLength(l float64) float64 {
+	return tAtLength(s, s.Length(), l, DefaultLengthTolerance)
+}
@@ -0,0 +1,152 @@
+package parametric2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+func TestQuadCurve_interface(t *testing.T) {
+	var curve T = NewQuadCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0})
+	if curve == nil {
+		t.Errorf("quadratic curve does not implement interface T")
+	}
+}
+
+func TestQuadCurveBBox(t *testing.T) {
+	v := NewQuadCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0})
+	want := vec2.Rect{Min: vec2.T{0, 0}, Max: vec2.T{2, 1}}
+	got := v.BBox()
+	if got.Min != want.Min {
+		t.Errorf("BBox Min failed: got %v, want %v", got.Min, want.Min)
+	}
+	if got.Max != want.Max {
+		t.Errorf("BBox Max failed: got %v, want %v", got.Max, want.Max)
+	}
+}
+
+func TestQuadCurveAt(t *testing.T) {
+	v := NewQuadCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0})
+	want := vec2.T{1, 1}
+	got := v.At(0.5)
+	if got != want {
+		t.Errorf("At failed: got %v, want %v", got, want)
+	}
+}
+
+func TestQuadCurveTangent(t *testing.T) {
+	v := NewQuadCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0})
+	want := vec2.T{2, 0}
+	got := v.Tangent(0.5)
+	if got != want {
+		t.Errorf("Tangent failed: got %v, want %v", got, want)
+	}
+}
+
+func TestQuadCurveNNormal(t *testing.T) {
+	v := NewQuadCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0})
+	want := vec2.T{0, 1}
+	got := v.NNormal(0.5)
+	e := 1e-15
+	if math.Abs(got[0]-want[0]) > e || math.Abs(got[1]-want[1]) > e {
+		t.Errorf("NNormal failed: got %v, want %v", got, want)
+	}
+}
+
+func TestQuadCurveNNormal_CoincidentControlPoint(t *testing.T) {
+	for _, v := range []QuadCurve{
+		NewQuadCurve(vec2.T{0, 0}, vec2.T{0, 0}, vec2.T{3, 4}),
+		NewQuadCurve(vec2.T{0, 0}, vec2.T{3, 4}, vec2.T{3, 4}),
+	} {
+		if got := v.spline.P1; got != (vec2.T{0, 0}) && got != (vec2.T{3, 4}) {
+			t.Errorf("NewQuadCurve moved the control point to %v", got)
+		}
+		for _, u := range []float64{0, 1} {
+			if got, want := v.NNormal(u), (vec2.T{-0.8, 0.6}); !vecNear(got, want, 1e-15) {
+				t.Errorf("NNormal(%v) = %v, want %v", u, got, want)
+			}
+		}
+	}
+}
+
+// degreeElevated returns the cubic Curve that traces the same path as
+// the quadratic Bezier defined by p0, p1, p2.
+func degreeElevated(p0, p1, p2 vec2.T) Curve {
+	c1 := vec2.Interpolate(&p0, &p1, 2.0/3.0)
+	c2 := vec2.Interpolate(&p2, &p1, 2.0/3.0)
+	return NewCurve(p0, c1, c2, p2)
+}
+
+func TestQuadCurveWall(t *testing.T) {
+	p0, p1, p2 := vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0}
+	v := NewQuadCurve(p0, p1, p2)
+	c := degreeElevated(p0, p1, p2)
+	got, gotPts, err := v.Wall(4, 10, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, wantPts, err := c.Wall(4, 10, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) || len(gotPts) != len(wantPts) {
+		t.Fatalf("Wall failed: got %v triangles and %v points, want %v and %v", len(got), len(gotPts), len(want), len(wantPts))
+	}
+	e := 1e-12
+	for i, tri := range got {
+		for j := range tri {
+			if math.Abs(tri[j][0]-want[i][j][0]) > e || math.Abs(tri[j][1]-want[i][j][1]) > e || tri[j][2] != want[i][j][2] {
+				t.Errorf("Wall #%v failed: got %v, want %v", i, tri, want[i])
+			}
+		}
+	}
+}
+
+func TestQuadCurveBevel(t *testing.T) {
+	p0, p1, p2 := vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0}
+	v := NewQuadCurve(p0, p1, p2)
+	c := degreeElevated(p0, p1, p2)
+	prevNN, nextNN := v.NNormal(0), v.NNormal(1)
+	got, gotPts, err := v.Bevel(4, 0.1, 45, 10, false, &prevNN, &nextNN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prevNN, nextNN = c.NNormal(0), c.NNormal(1)
+	want, wantPts, err := c.Bevel(4, 0.1, 45, 10, false, &prevNN, &nextNN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) || len(gotPts) != len(wantPts) {
+		t.Fatalf("Bevel failed: got %v triangles and %v points, want %v and %v", len(got), len(gotPts), len(want), len(wantPts))
+	}
+	e := 1e-12
+	for i, pt := range gotPts {
+		if math.Abs(pt.X-wantPts[i].X) > e || math.Abs(pt.Y-wantPts[i].Y) > e {
+			t.Errorf("Bevel point #%v failed: got %v, want %v", i, *pt, *wantPts[i])
+		}
+	}
+}
+
+func TestQuadCurveLength(t *testing.T) {
+	// B(t) = (2t, 4t(1-t)) has speed 2*sqrt(1 + (2-4t)^2), whose integral
+	// over [0,1] is sqrt(5) + asinh(2)/2.
+	v := NewQuadCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0})
+	want := math.Sqrt(5) + 0.5*math.Asinh(2)
+	got := v.Length()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("Length failed: got %v, want %v", got, want)
+	}
+}
+
+func TestQuadCurveSplitAndSub(t *testing.T) {
+	v := NewQuadCurve(vec2.T{0, 0}, vec2.T{1, 2}, vec2.T{2, 0})
+	checkSplitAndSub(t, v)
+	a, b := v.Split(0.5)
+	if _, p1, _ := a.(QuadCurve).Points(); p1 != (vec2.T{0.5, 1}) {
+		t.Errorf("Split(0.5) first control point = %v, want [0.5 1]", p1)
+	}
+	if p0, _, _ := b.(QuadCurve).Points(); p0 != (vec2.T{1, 1}) {
+		t.Errorf("Split(0.5) second start point = %v, want [1 1]", p0)
+	}
+}
@@ -0,0 +1,136 @@
+package parametric2d
+
+import "math"
+
+// quadraticRoots returns the real roots of a*t^2 + b*t + c = 0.
+// If 'a' is (nearly) zero compared with the other coefficients, the
+// equation is solved as a linear one, so that scaling all of the
+// coefficients does not change the roots.
+func quadraticRoots(a, b, c float64) []float64 {
+	const eps = 1e-12
+	scale := math.Max(math.Abs(a), math.Max(math.Abs(b), math.Abs(c)))
+	if math.Abs(a) <= eps*scale {
+		if math.Abs(b) <= eps*scale {
+			return nil
+		}
+		return []float64{-c / b}
+	}
+	disc := b*b - 4*a*c
+	if disc < 0 {
+		return nil
+	}
+	if disc == 0 {
+		return []float64{-b / (2 * a)}
+	}
+	// Avoid catastrophic cancellation by computing the larger root first.
+	sq := math.Sqrt(disc)
+	q := -0.5 * (b + math.Copysign(sq, b))
+	return []float64{q / a, c / q}
+}
+
+// rootsInUnitInterval filters roots to those strictly between 0 and 1.
+func rootsInUnitInterval(roots []float64) []float64 {
+	var r []float64
+	for _, t := range roots {
+		if t > 0 && t < 1 {
+			r = append(r, t)
+		}
+	}
+	return r
+}
+
+// polyEval evaluates the polynomial c[0] + c[1]*t + c[2]*t^2 + ...
+func polyEval(c []float64, t float64) float64 {
+	var v float64
+	for i := len(c) - 1; i >= 0; i-- {
+		v = v*t + c[i]
+	}
+	return v
+}
+
+// polyDeriv returns the derivative of the polynomial.
+func polyDeriv(c []float64) []float64 {
+	if len(c) <= 1 {
+		return nil
+	}
+	d := make([]float64, len(c)-1)
+	for i := range d {
+		d[i] = float64(i+1) * c[i+1]
+	}
+	return d
+}
+
+// polyMul returns the product of two polynomials.
+func polyMul(a, b []float64) []float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	r := make([]float64, len(a)+len(b)-1)
+	for i, x := range a {
+		for j, y := range b {
+			r[i+j] += x * y
+		}
+	}
+	return r
+}
+
+// polyRoots returns the real roots of the polynomial within [lo, hi] in
+// increasing order.
+//
+// The roots of the derivative (found recursively) split the interval into
+// pieces on which the polynomial is monotonic, so each piece contains at
+// most one root, which is then found by bisection.
+func polyRoots(c []float64, lo, hi float64) []float64 {
+	n := len(c)
+	for n > 0 && c[n-1] == 0 {
+		n--
+	}
+	c = c[:n]
+	if n <= 1 {
+		return nil
+	}
+	if n == 2 {
+		t := -c[0] / c[1]
+		if t < lo || t > hi {
+			return nil
+		}
+		return []float64{t}
+	}
+	bounds := append([]float64{lo}, polyRoots(polyDeriv(c), lo, hi)...)
+	bounds = append(bounds, hi)
+	var r []float64
+	add := func(t float64) {
+		if len(r) == 0 || t-r[len(r)-1] > 1e-12 {
+			r = append(r, t)
+		}
+	}
+	for i := 0; i < len(bounds)-1; i++ {
+		a, b := bounds[i], bounds[i+1]
+		fa, fb := polyEval(c, a), polyEval(c, b)
+		if fa == 0 {
+			add(a)
+			continue
+		}
+		if fb == 0 || (fa < 0) == (fb < 0) {
+			continue
+		}
+		for j := 0; j < 100 && b-a > 1e-15; j++ {
+			m := 0.5 * (a + b)
+			fm := polyEval(c, m)
+			if fm == 0 {
+				a, b = m, m
+				break
+			}
+			if (fm < 0) == (fa < 0) {
+				a, fa = m, fm
+			} else {
+				b = m
+			}
+		}
+		add(0.5 * (a + b))
+	}
+	if polyEval(c, hi) == 0 {
+		add(hi)
+	}
+	return r
+}
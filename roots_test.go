@@ -0,0 +1,38 @@
+package parametric2d
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuadraticRoots(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, c float64
+		want    []float64
+	}{
+		{"zero", 0, 0, 0, nil},
+		{"constant", 0, 0, 1, nil},
+		{"linear", 0, 2, -1, []float64{0.5}},
+		{"nearly linear", 1e-14, 2, -1, []float64{0.5}},
+		{"no real roots", 1, 0, 1, nil},
+		{"double root", 1, -1, 0.25, []float64{0.5}},
+		// (t-0.25)(t-0.75) at various scales.
+		{"unit", 1, -1, 0.1875, []float64{0.75, 0.25}},
+		{"tiny", 1e-15, -1e-15, 0.1875e-15, []float64{0.75, 0.25}},
+		{"huge", 1e15, -1e15, 0.1875e15, []float64{0.75, 0.25}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := quadraticRoots(tt.a, tt.b, tt.c)
+			if len(got) != len(tt.want) {
+				t.Fatalf("quadraticRoots = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-12 {
+					t.Errorf("quadraticRoots = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
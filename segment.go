@@ -0,0 +1,245 @@
+package parametric2d
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gmlewis/go-poly2tri"
+	"github.com/gmlewis/go3d/float64/vec2"
+	"github.com/gmlewis/go3d/float64/vec3"
+)
+
+// subdivide returns the parametric 't' values along the segment
+// such that the tangent between two points never exceeds `maxDegrees`.
+func subdivide(s T, maxDegrees float64) []float64 {
+	// Start with 3 points, and subdivide as necessary:
+	ts := []float64{0, 0.5, 1}
+	tangents := []vec2.T{s.NTangent(0), s.NTangent(0.5), s.NTangent(1)}
+	maxRadians := math.Abs(maxDegrees * math.Pi / 180.0)
+	i := 0
+	for i < len(ts)-1 {
+		if ts[i+1]-ts[i] < 1e-2 {
+			if logger.enabled() {
+				logger.Debug("stopping subdivision", "i", i, "t0", ts[i], "t1", ts[i+1])
+			}
+			i++
+			continue
+		}
+		angle := math.Abs(vec2.Angle(&tangents[i], &tangents[i+1]))
+		if angle > maxRadians { // Subdivide
+			m := 0.5 * (ts[i] + ts[i+1])
+			tan := s.NTangent(m)
+			// Insert the new t and tangent into the slice.
+			ts = append(ts, 0) // make room
+			copy(ts[i+2:], ts[i+1:])
+			ts[i+1] = m
+			tangents = append(tangents, vec2.T{0, 0}) // make room
+			copy(tangents[i+2:], tangents[i+1:])
+			tangents[i+1] = tan
+		} else {
+			i++
+		}
+	}
+	return ts
+}
+
+// wall extrudes a segment into a 3D wall using the provided
+// subdivision points 'ts'.
+func wall(s T, ts []float64, height float64, flipNormals bool) ([]Triangle3D, poly2tri.PointArray, error) {
+	if isDegenerate(s) {
+		return nil, nil, ErrDegenerateSegment
+	}
+	num := len(ts)
+	if num <= 0 {
+		return []Triangle3D{}, poly2tri.PointArray{}, nil
+	}
+	v := make([]Triangle3D, 0, 2*num)
+	floorPts := make(poly2tri.PointArray, 0, num-1)
+	for i := 0; i < num-1; i++ {
+		p0 := s.At(ts[i])
+		p1 := s.At(ts[i+1])
+		t := Triangle3D{
+			vec3.T{p0[0], p0[1], 0},
+			vec3.T{p1[0], p1[1], height},
+			vec3.T{p0[0], p0[1], height},
+		}
+		if flipNormals {
+			t[1], t[2] = t[2], t[1]
+		}
+		v = append(v, t)
+		// if i == 0 {
+		// 	floorPts = append(floorPts, poly2tri.NewPoint(p0[0], p0[1]))
+		// }
+		t = Triangle3D{
+			vec3.T{p0[0], p0[1], 0},
+			vec3.T{p1[0], p1[1], 0},
+			vec3.T{p1[0], p1[1], height},
+		}
+		if flipNormals {
+			t[1], t[2] = t[2], t[1]
+		}
+		v = append(v, t)
+		floorPts = append(floorPts, poly2tri.NewPoint(p1[0], p1[1]))
+	}
+	return v, floorPts, nil
+}
+
+// normalAngle returns the angle in radians between the normalized vectors.
+// Unlike vec2.Angle, it treats nearly identical vectors whose dot product
+// rounds to slightly more than 1 as parallel.
+func normalAngle(a, b *vec2.T) float64 {
+	return math.Acos(math.Max(-1, math.Min(1, vec2.Dot(a, b))))
+}
+
+// isDegenerate returns true if the segment collapses to a single point.
+func isDegenerate(s T) bool {
+	bbox := s.BBox()
+	return bbox.Min == bbox.Max
+}
+
+// bevel returns a 3D beveled object based on the provided segment
+// using the subdivision points 'ts'.
+func bevel(s T, ts []float64, height, offset, deg float64, flipNormals bool, prevNN, nextNN *vec2.T) ([]Triangle3D, poly2tri.PointArray, error) {
+	h := offset * math.Tan(deg*math.Pi/180.0)
+	return miterBand(s, ts, height, height+h, offset, flipNormals, prevNN, nextNN)
+}
+
+// miterBand returns the band of triangles between the segment at height
+// 'z0' and its offset by 'offset' along its normals at height 'z1', using
+// the subdivision points 'ts', along with the points of the offset. The
+// ends of the offset are mitered to meet the offsets of the neighboring
+// segments, whose normals are 'prevNN' and 'nextNN'.
+//
+// When the offset points of a slice overlap (which happens on concave
+// curves that are tighter than the offset), the slice is collapsed into
+// a single triangle.
+func miterBand(s T, ts []float64, z0, z1, offset float64, flipNormals bool, prevNN, nextNN *vec2.T) ([]Triangle3D, poly2tri.PointArray, error) {
+	if isDegenerate(s) {
+		return nil, nil, ErrDegenerateSegment
+	}
+	num := len(ts)
+	if num <= 0 {
+		return []Triangle3D{}, poly2tri.PointArray{}, nil
+	}
+	v := make([]Triangle3D, 0, 2*num)
+	bevelPts := make(poly2tri.PointArray, 0, num-1)
+	var collapsed *vec2.T
+	for i := 0; i < num-1; i++ {
+		p0 := s.At(ts[i])
+		p1 := s.At(ts[i+1])
+		n0 := s.NNormal(ts[i])
+		n1 := s.NNormal(ts[i+1])
+		if n0.IsZero() || n1.IsZero() {
+			return nil, nil, fmt.Errorf("bevel between t=%v and t=%v: %w", ts[i], ts[i+1], ErrUndefinedNormal)
+		}
+		n0f := offset
+		n1f := offset
+		if flipNormals {
+			n0[0], n0[1], n1[0], n1[1] = -n0[0], -n0[1], -n1[0], -n1[1]
+		}
+		if i == 0 {
+			angle0 := normalAngle(prevNN, &n0)
+			if *prevNN != n0 {
+				// Adjusting starting triangle n0f=1.0000532533019526, prevNN=[0.3559858534155444 -0.9344913440840459], n0=[0.37519651438861046 -0.9269452926632926], angle0=0.020639949379423816
+				// Created regular start-of-curve triangle:
+				// [[181.08017999999998 -499.24048 4] [182.489264893852 -499.72347812262666 5] [181.44581012281427 -500.17129744866037 5]]
+				n0f = offset / math.Cos(0.5*angle0)
+				if logger.enabled() {
+					logger.Debug("adjusting starting triangle", "n0f", n0f, "prevNN", *prevNN, "n0", n0, "angle0", angle0)
+				}
+				n0.Add(prevNN)
+				n0.Normalize()
+			} else if logger.enabled() {
+				logger.Debug("prevNN==n0", "n0", n0, "i", i, "t", ts[i], "p0", p0)
+			}
+		}
+		if i+1 == num-1 {
+			angle1 := normalAngle(&n1, nextNN)
+			if n1 != *nextNN {
+				n1f = offset / math.Cos(0.5*angle1)
+				n1.Add(nextNN)
+				n1.Normalize()
+			} else if logger.enabled() {
+				logger.Debug("n1==nextNN", "n1", n1, "i", i, "t", ts[i+1], "p1", p1)
+			}
+		}
+		p2 := n0.Scale(n0f).Add(&p0)
+		if collapsed != nil {
+			p2 = collapsed
+		}
+		p3 := n1.Scale(n1f).Add(&p1)
+		if len(edgeIntersections(p0, *p2, p1, *p3, 0)) > 0 { // p0-p2 intersects p1-p3 - delete p3 and add new triangle
+			if logger.enabled() {
+				logger.Debug("detected intersection", "i", i, "num", num, "t0", ts[i], "t1", ts[i+1])
+			}
+			if i+1 == num-1 { // End of the curve - need to add to the bevelPts
+				t := Triangle3D{
+					vec3.T{p0[0], p0[1], z0},
+					vec3.T{p1[0], p1[1], z0},
+					vec3.T{p3[0], p3[1], z1},
+				}
+				if logger.enabled() {
+					logger.Debug("created end-of-curve triangle", "triangle", t)
+				}
+				// Created end-of-curve triangle:
+				// Intersection: [182.07626125,-498.81274874999997]-[182.489264893852,-499.72347812262666]
+				//             X [183.09580999999997,-498.32642]-[182.09580999999997,-499.9444329390584]
+				// [[182.07626125 -498.81274874999997 4]
+				// [183.09580999999997 -498.32642 4]
+				// [182.09580999999997 -499.9444329390584 5]]
+				if flipNormals {
+					t[1], t[2] = t[2], t[1]
+				}
+				v = append(v, t)
+				bevelPts = append(bevelPts, poly2tri.NewPoint(p3[0], p3[1]))
+			} else {
+				// Collapse the overlapping offset point p3 onto p2 and carry p2
+				// forward as the top of the next slice.
+				// Do not add a bevelPts for this case because p2 has already been
+				// accounted for by the last slice (or by the last segment when i == 0).
+				t := Triangle3D{
+					vec3.T{p0[0], p0[1], z0},
+					vec3.T{p1[0], p1[1], z0},
+					vec3.T{p2[0], p2[1], z1},
+				}
+				if logger.enabled() {
+					logger.Debug("collapsed offset points", "i", i, "t", ts[i+1], "triangle", t)
+				}
+				if flipNormals {
+					t[1], t[2] = t[2], t[1]
+				}
+				v = append(v, t)
+				top := *p2
+				collapsed = &top
+			}
+		} else {
+			t := Triangle3D{
+				vec3.T{p0[0], p0[1], z0},
+				vec3.T{p3[0], p3[1], z1},
+				vec3.T{p2[0], p2[1], z1},
+			}
+			if i == 0 && logger.enabled() {
+				logger.Debug("created regular start-of-curve triangle", "triangle", t)
+			}
+			if flipNormals {
+				t[1], t[2] = t[2], t[1]
+			}
+			v = append(v, t)
+			t = Triangle3D{
+				vec3.T{p0[0], p0[1], z0},
+				vec3.T{p1[0], p1[1], z0},
+				vec3.T{p3[0], p3[1], z1},
+			}
+			if flipNormals {
+				t[1], t[2] = t[2], t[1]
+			}
+			v = append(v, t)
+			bevelPts = append(bevelPts, poly2tri.NewPoint(p3[0], p3[1]))
+			collapsed = nil
+		}
+	}
+	if logger.enabled() {
+		logger.Debug("bevel", "subdivisions", num, "triangles", len(v), "bevelPts", len(bevelPts))
+	}
+	return v, bevelPts, nil
+}
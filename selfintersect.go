@@ -0,0 +1,263 @@
+package parametric2d
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// ErrSelfIntersection is wrapped by a *SelfIntersectionError.
+var ErrSelfIntersection = errors.New("parametric2d: self-intersecting subpath")
+
+// SelfIntersection is a point where a SubPath crosses or touches itself.
+type SelfIntersection struct {
+	// Seg0 and Seg1 are the indices of the segments that meet (Seg0 <= Seg1)
+	// and T0 and T1 are the parametric positions along each of them.
+	Seg0, Seg1 int
+	T0, T1     float64
+	Point      vec2.T
+}
+
+// SelfIntersectionError reports every self-intersection within a Path.
+type SelfIntersectionError struct {
+	// Intersections lists the self-intersections of each offending
+	// SubPath, keyed by the SubPath's index within the Path.
+	Intersections map[int][]SelfIntersection
+}
+
+func (e *SelfIntersectionError) Error() string {
+	var keys []int
+	for k := range e.Intersections {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	var parts []string
+	for _, k := range keys {
+		for _, x := range e.Intersections[k] {
+			parts = append(parts, fmt.Sprintf("subpath %v: segments %v and %v at %v", k, x.Seg0, x.Seg1, x.Point))
+		}
+	}
+	return ErrSelfIntersection.Error() + ": " + strings.Join(parts, "; ")
+}
+
+// Unwrap returns ErrSelfIntersection.
+func (e *SelfIntersectionError) Unwrap() error {
+	return ErrSelfIntersection
+}
+
+// SelfIntersectionPolicy determines how Path.Wall and Path.Bevel handle
+// SubPaths that intersect themselves.
+type SelfIntersectionPolicy int
+
+const (
+	// ResolveSelfIntersections resolves crossings according to the Path's
+	// FillRule (see Path.SolidRegions). It is the default.
+	ResolveSelfIntersections SelfIntersectionPolicy = iota
+	// RejectSelfIntersections returns a *SelfIntersectionError before
+	// anything is triangulated.
+	RejectSelfIntersections
+	// SplitSelfIntersections splits each self-intersecting SubPath into
+	// simple loops (see SubPath.SplitLoops) before resolving the result
+	// according to the Path's FillRule.
+	SplitSelfIntersections
+)
+
+// selfIntersectionTolerance is the distance in 't' from the shared end
+// of two adjacent segments within which their meeting is not reported.
+const selfIntersectionTolerance = 1e-6
+
+// SelfIntersections returns every point where the SubPath crosses or
+// touches itself, other than where consecutive segments join.
+func (s *SubPath) SelfIntersections() []SelfIntersection {
+	n := len(s.Segments)
+	bboxes := make([]vec2.Rect, n)
+	for i, seg := range s.Segments {
+		bboxes[i] = seg.BBox()
+	}
+	var r []SelfIntersection
+	for i, a := range s.Segments {
+		if c, ok := a.(Curve); ok {
+			r = append(r, curveSelfIntersections(i, c)...)
+		}
+		for j := i + 1; j < n; j++ {
+			if !bboxesOverlap(bboxes[i], bboxes[j], 0) {
+				continue
+			}
+			for _, x := range Intersect(a, s.Segments[j]) {
+				const tol = selfIntersectionTolerance
+				if j == i+1 && x.T0 > 1-tol && x.T1 < tol {
+					continue // the end of 'a' joins the start of the next segment
+				}
+				if i == 0 && j == n-1 && x.T0 < tol && x.T1 > 1-tol {
+					continue // the last segment joins the start of the first
+				}
+				r = append(r, SelfIntersection{Seg0: i, Seg1: j, T0: x.T0, T1: x.T1, Point: x.Point})
+			}
+		}
+	}
+	return r
+}
+
+// curveSelfIntersections returns the loop point of a cubic Bezier curve,
+// if it has one. The curve is cut where its tangent has turned by 90
+// degrees so that no piece can loop back on itself, and the pieces are
+// intersected with each other.
+func curveSelfIntersections(seg int, c Curve) []SelfIntersection {
+	ts := subdivide(c, 90)
+	var r []SelfIntersection
+	for i := 0; i < len(ts)-1; i++ {
+		a := c.Sub(ts[i], ts[i+1])
+		for j := i + 1; j < len(ts)-1; j++ {
+			b := c.Sub(ts[j], ts[j+1])
+			for _, x := range Intersect(a, b) {
+				t0 := ts[i] + x.T0*(ts[i+1]-ts[i])
+				t1 := ts[j] + x.T1*(ts[j+1]-ts[j])
+				if t1-t0 < selfIntersectionTolerance {
+					continue // the pieces join here
+				}
+				if len(r) == 0 || math.Abs(r[len(r)-1].T0-t0) > selfIntersectionTolerance {
+					r = append(r, SelfIntersection{Seg0: seg, Seg1: seg, T0: t0, T1: t1, Point: x.Point})
+				}
+			}
+		}
+	}
+	return r
+}
+
+// Validate checks every SubPath of the Path for self-intersections and
+// returns a *SelfIntersectionError that lists all of them, or nil.
+func (p *Path) Validate() error {
+	var e *SelfIntersectionError
+	for i, sp := range p.SubPaths {
+		if xs := sp.SelfIntersections(); len(xs) > 0 {
+			if e == nil {
+				e = &SelfIntersectionError{Intersections: map[int][]SelfIntersection{}}
+			}
+			e.Intersections[i] = xs
+		}
+	}
+	if e == nil {
+		return nil
+	}
+	return e
+}
+
+// loopPiece is part of a segment that ends at the self-intersection
+// with the given id (or -1).
+type loopPiece struct {
+	seg  T
+	endX int
+}
+
+// SplitLoops splits the SubPath at each of its self-intersections and
+// returns the resulting simple loops, which keep the original segments
+// (or the parts of them between intersections). A SubPath without
+// self-intersections is returned as is.
+//
+// The loops that wind in the same direction as the SubPath keep its
+// FlipNormals setting and the others have it toggled, so that, for
+// example, both lobes of a figure-eight have normals that face their
+// interiors.
+func (s *SubPath) SplitLoops() []*SubPath {
+	xs := s.SelfIntersections()
+	if len(xs) == 0 {
+		return []*SubPath{s}
+	}
+	type cut struct {
+		t  float64
+		id int
+	}
+	cuts := make([][]cut, len(s.Segments))
+	for id, x := range xs {
+		cuts[x.Seg0] = append(cuts[x.Seg0], cut{x.T0, id})
+		cuts[x.Seg1] = append(cuts[x.Seg1], cut{x.T1, id})
+	}
+	const tol = selfIntersectionTolerance
+	var pieces []loopPiece
+	var atStart []int // intersections at the very start of the SubPath
+	for i, seg := range s.Segments {
+		cs := cuts[i]
+		sort.Slice(cs, func(a, b int) bool { return cs[a].t < cs[b].t })
+		prev := 0.0
+		for _, c := range cs {
+			switch {
+			case c.t < tol && len(pieces) == 0:
+				atStart = append(atStart, c.id)
+			case c.t-prev < tol:
+				// The intersection is at the start of this piece, which is
+				// the end of the last one.
+				if pieces[len(pieces)-1].endX < 0 {
+					pieces[len(pieces)-1].endX = c.id
+				}
+			case c.t > 1-tol:
+				// Handled below as the end of the segment.
+			default:
+				pieces = append(pieces, loopPiece{seg: seg.Sub(prev, c.t), endX: c.id})
+				prev = c.t
+			}
+		}
+		endX := -1
+		if len(cs) > 0 && cs[len(cs)-1].t > 1-tol {
+			endX = cs[len(cs)-1].id
+		}
+		pieces = append(pieces, loopPiece{seg: seg.Sub(prev, 1), endX: endX})
+	}
+	if len(atStart) > 0 && pieces[len(pieces)-1].endX < 0 {
+		pieces[len(pieces)-1].endX = atStart[0]
+	}
+
+	loops := splitPieces(pieces)
+	var r []*SubPath
+	ref := s.Orientation()
+	if ref == Collinear {
+		best := 0.0
+		for _, loop := range loops {
+			sp := piecesSubPath(loop)
+			if a := sp.SignedArea(); math.Abs(a) > best {
+				best, ref = math.Abs(a), sp.Orientation()
+			}
+		}
+	}
+	for _, loop := range loops {
+		sp := piecesSubPath(loop)
+		sp.FlipNormals = s.FlipNormals
+		if sp.Orientation() != ref {
+			sp.FlipNormals = !sp.FlipNormals
+		}
+		r = append(r, sp)
+	}
+	return r
+}
+
+func piecesSubPath(pieces []loopPiece) *SubPath {
+	sp := &SubPath{}
+	for _, p := range pieces {
+		sp.Segments = append(sp.Segments, p.seg)
+	}
+	return sp
+}
+
+// splitPieces splits a closed chain of pieces into loops by cutting it
+// at both visits to the same intersection.
+func splitPieces(pieces []loopPiece) [][]loopPiece {
+	for i, p := range pieces {
+		if p.endX < 0 {
+			continue
+		}
+		for j := i + 1; j < len(pieces); j++ {
+			if pieces[j].endX != p.endX {
+				continue
+			}
+			inner := append([]loopPiece(nil), pieces[i+1:j+1]...)
+			outer := append(append([]loopPiece(nil), pieces[j+1:]...), pieces[:i+1]...)
+			inner[len(inner)-1].endX = -1
+			outer[len(outer)-1].endX = -1
+			return append(splitPieces(inner), splitPieces(outer)...)
+		}
+	}
+	return [][]loopPiece{pieces}
+}
@@ -0,0 +1,140 @@
+package parametric2d
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+func TestSubPathSelfIntersections(t *testing.T) {
+	tests := []struct {
+		name string
+		d    string
+		want []SelfIntersection
+	}{
+		{"square", "M0 0h10v10h-10z", nil},
+		{"two segments", "M0 0h10z", nil},
+		{"figure eight", "M0 0L2 2L2 0L0 2z", []SelfIntersection{{Seg0: 0, Seg1: 2, T0: 0.5, T1: 0.5, Point: vec2.T{1, 1}}}},
+		// The spike doubles back over itself, so only consistency is checked.
+		{"spike", "M0 0h4v2h-2l1 -2l-1 2h-2z", []SelfIntersection{}},
+		{"cubic loop", "M0 0C4 3 -2 3 2 0z", []SelfIntersection{{Seg0: 0, Seg1: 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := mustParseSVGPath(t, tt.d).SubPaths[0]
+			got := sp.SelfIntersections()
+			if tt.want != nil && len(tt.want) == 0 {
+				// Only check that the reported points are consistent.
+				tt.want = got
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("SelfIntersections = %+v, want %+v", got, tt.want)
+			}
+			for i, x := range got {
+				if x.Seg0 != tt.want[i].Seg0 || x.Seg1 != tt.want[i].Seg1 {
+					t.Errorf("SelfIntersections #%v = %+v, want %+v", i, x, tt.want[i])
+				}
+				p0, p1 := sp.Segments[x.Seg0].At(x.T0), sp.Segments[x.Seg1].At(x.T1)
+				if !vecNear(p0, x.Point, 1e-7) || !vecNear(p1, x.Point, 1e-7) {
+					t.Errorf("SelfIntersections #%v = %+v, but the segments are at %v and %v", i, x, p0, p1)
+				}
+				if x.Seg0 == x.Seg1 && math.Abs(x.T0-x.T1) < 1e-3 {
+					t.Errorf("SelfIntersections #%v = %+v, want distinct positions", i, x)
+				}
+			}
+		})
+	}
+}
+
+func TestPathValidate(t *testing.T) {
+	p := mustParseSVGPath(t, "M-10 -10h30v30h-30z M0 0L2 2L2 0L0 2z")
+	if loops := p.SubPaths[0].SplitLoops(); len(loops) != 1 || loops[0] != p.SubPaths[0] {
+		t.Errorf("SplitLoops of a simple SubPath = %v, want it unchanged", loops)
+	}
+	err := p.Validate()
+	if !errors.Is(err, ErrSelfIntersection) {
+		t.Fatalf("Validate = %v, want ErrSelfIntersection", err)
+	}
+	var se *SelfIntersectionError
+	if !errors.As(err, &se) || len(se.Intersections) != 1 || len(se.Intersections[1]) != 1 {
+		t.Fatalf("Validate = %#v, want one intersection in subpath 1", err)
+	}
+	if want := "subpath 1: segments 0 and 2 at [1 1]"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Validate = %q, want it to contain %q", err, want)
+	}
+	if err := mustParseSVGPath(t, "M0 0h10v10h-10z").Validate(); err != nil {
+		t.Errorf("Validate = %v, want nil", err)
+	}
+}
+
+func TestSubPathSplitLoops(t *testing.T) {
+	// A figure eight whose larger lobe winds clockwise.
+	sp := mustParseSVGPath(t, "M0 0L3 3L3 0L0 1z").SubPaths[0]
+	sp.AutoFlipNormals()
+	loops := sp.SplitLoops()
+	if len(loops) != 2 {
+		t.Fatalf("SplitLoops = %v loops, want 2", len(loops))
+	}
+	wantAreas := []float64{0.375, -3.375}
+	for i, loop := range loops {
+		if a := loop.SignedArea(); math.Abs(a-wantAreas[0]) > 1e-9 && math.Abs(a-wantAreas[1]) > 1e-9 {
+			t.Errorf("loop #%v area = %v, want one of %v", i, a, wantAreas)
+		}
+		if len(loop.SelfIntersections()) != 0 {
+			t.Errorf("loop #%v still intersects itself", i)
+		}
+		bbox := loop.BBox()
+		center := vec2.Interpolate(&bbox.Min, &bbox.Max, 0.5)
+		if !normalsPointInward(loop, center) {
+			t.Errorf("loop #%v normals do not face its interior", i)
+		}
+	}
+
+	// The curves of a looping cubic are kept.
+	sp = mustParseSVGPath(t, "M0 0C4 3 -2 3 2 0z").SubPaths[0]
+	loops = sp.SplitLoops()
+	if len(loops) != 2 {
+		t.Fatalf("SplitLoops = %v loops, want 2", len(loops))
+	}
+	for i, loop := range loops {
+		if _, ok := loop.Segments[0].(Curve); !ok {
+			t.Errorf("loop #%v segment #0 = %T, want Curve", i, loop.Segments[0])
+		}
+		first, last := loop.Segments[0].At(0), loop.Segments[len(loop.Segments)-1].At(1)
+		if !vecNear(first, last, 1e-7) {
+			t.Errorf("loop #%v is not closed: %v != %v", i, first, last)
+		}
+	}
+}
+
+func TestPathWall_OnSelfIntersection(t *testing.T) {
+	const d = "M0 0L2 2L2 0L0 2z"
+	tests := []struct {
+		policy  SelfIntersectionPolicy
+		wantErr bool
+	}{
+		{ResolveSelfIntersections, false},
+		{RejectSelfIntersections, true},
+		{SplitSelfIntersections, false},
+	}
+	for _, tt := range tests {
+		p := mustParseSVGPath(t, d)
+		p.OnSelfIntersection = tt.policy
+		tris, err := p.Wall(1, 10)
+		if tt.wantErr {
+			if !errors.Is(err, ErrSelfIntersection) {
+				t.Errorf("policy %v: Wall = %v, want ErrSelfIntersection", tt.policy, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("policy %v: Wall = %v", tt.policy, err)
+		}
+		if got := projectedArea(tris); math.Abs(got-2) > 1e-9 {
+			t.Errorf("policy %v: floor area = %v, want 2", tt.policy, got)
+		}
+	}
+}
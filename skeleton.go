@@ -0,0 +1,667 @@
+package parametric2d
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/gmlewis/go-poly2tri"
+	"github.com/gmlewis/go3d/float64/vec2"
+	"github.com/gmlewis/go3d/float64/vec3"
+)
+
+// ErrSkeletonStalled is returned when the events of a straight skeleton
+// keep coming without the wavefront vanishing, which only degenerate
+// input is expected to cause.
+var ErrSkeletonStalled = errors.New("parametric2d: straight skeleton did not converge")
+
+// StraightSkeleton returns the arcs of the straight skeleton of the solid
+// regions of the Path (see SolidRegions), flattened using `maxDegrees`.
+//
+// The straight skeleton is traced by the vertices of the boundary as it
+// shrinks, with every edge moving inward at the same speed and keeping
+// its direction. Each arc is returned as a pair of 3D points whose z
+// coordinate is the distance that the boundary has moved, so the arcs
+// are the ridges and valleys of a roof with 45 degree slopes built over
+// the solid.
+func (p *Path) StraightSkeleton(maxDegrees float64) ([][2]vec3.T, error) {
+	if len(p.SubPaths) == 0 {
+		return nil, nil
+	}
+	p, err := p.prepare(maxDegrees)
+	if err != nil {
+		return nil, err
+	}
+	var rings [][]vec2.T
+	for _, g := range p.nest(maxDegrees) {
+		rings = append(rings, g.solidRings(maxDegrees)...)
+	}
+	w := newWavefront(rings)
+	if err := w.advance(math.Inf(1)); err != nil {
+		return nil, err
+	}
+	w.finish()
+	return w.arcs, nil
+}
+
+// solidRings returns the flattened SubPaths of the group, each ordered so
+// that the solid lies on its left: the outer counter-clockwise and the
+// holes clockwise.
+func (g *subPathGroup) solidRings(maxDegrees float64) [][]vec2.T {
+	var r [][]vec2.T
+	for i, sp := range append([]*SubPath{g.outer}, g.holes...) {
+		ring := sp.Flatten(maxDegrees)
+		if a := polygonArea(ring); (i == 0) != (a > 0) {
+			reversePoints(ring)
+		}
+		r = append(r, ring)
+	}
+	return r
+}
+
+// profileRoof returns the roof over the solid bounded by the rings (each
+// with the solid on its left) whose cross section follows the profile
+// points (see BevelProfile) from height 'z', along with the rings of the
+// shrunken boundary at the last inset of the profile.
+func profileRoof(rings [][]vec2.T, pts []vec2.T, z float64) ([]Triangle3D, [][]vec2.T, error) {
+	w := newWavefront(rings)
+	var r []Triangle3D
+	for k := 1; k < len(pts); k++ {
+		a, b := pts[k-1], pts[k]
+		if b[0] <= a[0] {
+			// The profile rises (or falls) straight up from the wavefront.
+			if b[1] != a[1] {
+				for _, ring := range w.front() {
+					r = append(r, stitchRings(ring3D(ring, z+a[1]), ring3D(ring, z+b[1]))...)
+				}
+			}
+			continue
+		}
+		n := len(w.faces)
+		if err := w.advance(b[0]); err != nil {
+			return nil, nil, err
+		}
+		w.cut()
+		slope := (b[1] - a[1]) / (b[0] - a[0])
+		r = w.slopedFaces(r, n, z+a[1], a[0], slope)
+	}
+	return r, w.front(), nil
+}
+
+// slopedFaces appends the faces recorded from index 'from' on, with each
+// point raised to 'z' plus 'slope' times its time after 't0'.
+func (w *wavefront) slopedFaces(r []Triangle3D, from int, z, t0, slope float64) []Triangle3D {
+	for _, f := range w.faces[from:] {
+		t := make(Triangle3D, len(f))
+		for i, v := range f {
+			t[i] = vec3.T{v[0], v[1], z + slope*(v[2]-t0)}
+		}
+		r = append(r, t)
+	}
+	return r
+}
+
+// capRings triangulates the region bounded by the rings (each with the
+// solid on its left) at height 'z'.
+func capRings(r []Triangle3D, rings [][]vec2.T, z, maxDegrees float64) []Triangle3D {
+	p := &Path{}
+	loops := map[*SubPath][]vec2.T{}
+	for _, ring := range rings {
+		sp := &SubPath{}
+		for i, a := range ring {
+			sp.Segments = append(sp.Segments, NewLine(a, ring[(i+1)%len(ring)]))
+		}
+		p.SubPaths = append(p.SubPaths, sp)
+		loops[sp] = ring
+	}
+	for _, g := range p.nest(maxDegrees) {
+		group := [][]vec2.T{loops[g.outer]}
+		for _, hole := range g.holes {
+			group = append(group, loops[hole])
+		}
+		r = capGroup(r, group, z)
+	}
+	return r
+}
+
+// capGroup triangulates the region inside the first ring and outside the
+// others (its holes) at height 'z'.
+func capGroup(r []Triangle3D, rings [][]vec2.T, z float64) []Triangle3D {
+	pts := make([]poly2tri.PointArray, len(rings))
+	for i, ring := range rings {
+		for _, a := range ring {
+			pts[i] = append(pts[i], poly2tri.NewPoint(a[0], a[1]))
+		}
+	}
+	if logger.enabled() {
+		logger.Debug("capping rings", "points", len(pts[0]), "holes", len(pts)-1)
+	}
+	sc := poly2tri.New(pts[0])
+	for _, hole := range pts[1:] {
+		sc.AddHole(hole)
+	}
+	return Triangulate(sc.Triangulate(), r, z)
+}
+
+// wfVertex is a vertex of the shrinking boundary (the wavefront). It
+// moves with a constant velocity from the point where it was created,
+// along the bisector of its two edges.
+type wfVertex struct {
+	origin vec2.T
+	born   float64
+	vel    vec2.T
+	// dir is the unit direction of the edge from this vertex to next.
+	dir        vec2.T
+	prev, next *wfVertex
+	// twin is the other vertex created by the split that created this one.
+	twin *wfVertex
+	// breaks are the times at which the faces beside the vertex were cut.
+	breaks []float64
+	// tops are the indices of the faces that hold the top of the face
+	// swept by the edge to next, as its second and third points.
+	tops  []int
+	spike bool // the edges double back on each other
+	alive bool
+}
+
+// at returns the position of the vertex at time 't'.
+func (v *wfVertex) at(t float64) vec2.T {
+	dt := t - v.born
+	return vec2.T{v.origin[0] + dt*v.vel[0], v.origin[1] + dt*v.vel[1]}
+}
+
+// addBreak records that a face beside the vertex was cut at time 't'.
+func (v *wfVertex) addBreak(t float64) {
+	if t > v.born && (len(v.breaks) == 0 || t > v.breaks[len(v.breaks)-1]) {
+		v.breaks = append(v.breaks, t)
+	}
+}
+
+// chain returns the times from 't0' to 't1' at which the vertex's path is
+// divided by the faces beside it.
+func (v *wfVertex) chain(t0, t1 float64) []float64 {
+	r := []float64{t0}
+	if t1 <= t0 {
+		return r
+	}
+	for _, t := range v.breaks {
+		if t > t0 && t < t1 {
+			r = append(r, t)
+		}
+	}
+	return append(r, t1)
+}
+
+// reflex reports whether the vertex turns away from the solid.
+func (v *wfVertex) reflex() bool {
+	return cross2(v.prev.dir, v.dir) < -1e-12
+}
+
+// adjacent reports whether the edge from 'e' to e.next touches 'v' at
+// time 't', either directly or through the twin that 'v' was split from.
+func (v *wfVertex) adjacent(e *wfVertex, t, eps float64) bool {
+	if e == v || e.next == v {
+		return true
+	}
+	if v.twin == nil || !v.twin.alive || (e != v.twin && e.next != v.twin) {
+		return false
+	}
+	return distance(v.at(t), v.twin.at(t)) <= eps
+}
+
+// setVelocity moves the vertex so that both of its edges move to their
+// left at unit speed.
+func (v *wfVertex) setVelocity() {
+	n0 := vec2.T{-v.prev.dir[1], v.prev.dir[0]}
+	n1 := vec2.T{-v.dir[1], v.dir[0]}
+	d := 1 + vec2.Dot(&n0, &n1)
+	v.spike = d < 1e-9
+	if v.spike {
+		v.vel = vec2.T{}
+		return
+	}
+	v.vel = vec2.T{(n0[0] + n1[0]) / d, (n0[1] + n1[1]) / d}
+}
+
+// wavefront simulates the shrinking boundary of a solid to build its
+// straight skeleton. Edge events (an edge shrinking to nothing) merge the
+// edge's vertices, and split events (a reflex vertex running into an edge)
+// split a ring into two. The arcs traced by the vertices and the faces
+// swept by the edges are recorded, with the time as their z coordinate.
+type wavefront struct {
+	verts []*wfVertex
+	eps   float64
+	now   float64
+	// base is the time from which the next faces are swept.
+	base  float64
+	arcs  [][2]vec3.T
+	faces []Triangle3D
+	// maxEvents is the number of events after which advance gives up.
+	maxEvents int
+}
+
+// newWavefront returns the wavefront of the rings, each of which has the
+// solid on its left.
+func newWavefront(rings [][]vec2.T) *wavefront {
+	var all []vec2.T
+	for _, ring := range rings {
+		all = append(all, ring...)
+	}
+	bbox := polygonBBox(all)
+	size := math.Max(bbox.Max[0]-bbox.Min[0], bbox.Max[1]-bbox.Min[1])
+	w := &wavefront{eps: 1e-9 * math.Max(size, 1)}
+	for _, ring := range rings {
+		var pts []vec2.T
+		for _, p := range ring {
+			if len(pts) == 0 || distance(p, pts[len(pts)-1]) > w.eps {
+				pts = append(pts, p)
+			}
+		}
+		for len(pts) > 1 && distance(pts[0], pts[len(pts)-1]) <= w.eps {
+			pts = pts[:len(pts)-1]
+		}
+		if len(pts) < 3 {
+			continue
+		}
+		first := len(w.verts)
+		for _, p := range pts {
+			w.verts = append(w.verts, &wfVertex{origin: p, alive: true})
+		}
+		ring := w.verts[first:]
+		for i, v := range ring {
+			v.next = ring[(i+1)%len(ring)]
+			v.next.prev = v
+			v.dir = vec2.Sub(&v.next.origin, &v.origin)
+			v.dir.Normalize()
+		}
+		for _, v := range ring {
+			v.setVelocity()
+		}
+	}
+	w.maxEvents = 10*len(w.verts)*len(w.verts) + 100
+	return w
+}
+
+// newVertex creates a vertex at 'p' at the current time.
+func (w *wavefront) newVertex(p, dir vec2.T, prev, next *wfVertex) *wfVertex {
+	v := &wfVertex{origin: p, born: w.now, dir: dir, prev: prev, next: next, alive: true}
+	prev.next, next.prev = v, v
+	w.verts = append(w.verts, v)
+	return v
+}
+
+// end records the arc traced by the vertex and removes it.
+func (w *wavefront) end(v *wfVertex) {
+	v.alive = false
+	a, b := v.origin, v.at(w.now)
+	if distance(a, b) > w.eps {
+		w.arcs = append(w.arcs, [2]vec3.T{{a[0], a[1], v.born}, {b[0], b[1], w.now}})
+	}
+}
+
+// sweep records the face swept by the edge from 'v' to v.next since
+// either of them was created or the faces were last cut.
+func (w *wavefront) sweep(v *wfVertex) {
+	w.sweepTo(v, nil)
+}
+
+// sweepTo is like sweep, but also divides the top of the face at 'via'
+// if it is not nil. The sides of the face are divided wherever the faces
+// beside them were cut, so that neighboring faces share their edges.
+func (w *wavefront) sweepTo(v *wfVertex, via *vec2.T) {
+	u := v.next
+	t0 := math.Max(w.base, math.Max(v.born, u.born))
+	left, right := v.chain(t0, w.now), u.chain(t0, w.now)
+	point := func(x *wfVertex, t float64) vec3.T {
+		p := x.at(t)
+		return vec3.T{p[0], p[1], t}
+	}
+	// Zip the sides together in order of time. The second and third
+	// points of each triangle are on opposite sides, so those of the
+	// last triangle are the ends of the top of the face.
+	var tris [][3]vec3.T
+	for i, j := 0, 0; i < len(left)-1 || j < len(right)-1; {
+		l, r := point(v, left[i]), point(u, right[j])
+		if i == len(left)-1 || (j < len(right)-1 && right[j+1] <= left[i+1]) {
+			j++
+			tris = append(tris, [3]vec3.T{r, point(u, right[j]), l})
+		} else {
+			i++
+			tris = append(tris, [3]vec3.T{l, r, point(v, left[i])})
+		}
+	}
+	if len(tris) == 0 {
+		// The face was swept up to now by an earlier event.
+		if via != nil {
+			w.divideTop(v, *via)
+		}
+		return
+	}
+	top := len(tris) - 1
+	if via != nil {
+		t := tris[top]
+		p := vec3.T{via[0], via[1], w.now}
+		tris = append(tris[:top], [3]vec3.T{t[0], t[1], p}, [3]vec3.T{t[0], p, t[2]})
+	}
+	v.tops = nil
+	for i, t := range tris {
+		if w.thin(vec2.T{t[0][0], t[0][1]}, vec2.T{t[1][0], t[1][1]}, vec2.T{t[2][0], t[2][1]}) {
+			continue
+		}
+		if i >= top {
+			v.tops = append(v.tops, len(w.faces))
+		}
+		w.faces = append(w.faces, Triangle3D{t[0], t[1], t[2]})
+	}
+	v.addBreak(w.now)
+	u.addBreak(w.now)
+}
+
+// divideTop divides the top of the face swept by the edge from 'v' to
+// v.next at the point 'p', which lies on it at the current time.
+func (w *wavefront) divideTop(v *wfVertex, p vec2.T) {
+	for _, i := range v.tops {
+		f := w.faces[i]
+		a, b := vec2.T{f[1][0], f[1][1]}, vec2.T{f[2][0], f[2][1]}
+		if math.Abs(f[1][2]-w.now) > w.eps || distance(p, a) <= w.eps || distance(p, b) <= w.eps {
+			continue
+		}
+		ab, ap := vec2.Sub(&b, &a), vec2.Sub(&p, &a)
+		if s := vec2.Dot(&ap, &ab); s <= 0 || s >= vec2.Dot(&ab, &ab) || math.Abs(cross2(ab, ap)) > w.eps*ab.Length() {
+			continue
+		}
+		q := vec3.T{p[0], p[1], w.now}
+		w.faces[i] = Triangle3D{f[0], f[1], q}
+		v.tops = append(v.tops, len(w.faces))
+		w.faces = append(w.faces, Triangle3D{f[0], q, f[2]})
+		return
+	}
+}
+
+// thin reports whether the triangle is so thin that it has no area to
+// within the wavefront's tolerance.
+func (w *wavefront) thin(a, b, c vec2.T) bool {
+	l := math.Max(distance(a, b), math.Max(distance(b, c), distance(c, a)))
+	return math.Abs(cross3(a, b, c)) <= w.eps*l
+}
+
+// advance processes the events until the wavefront vanishes or 'limit'
+// is reached, and then moves it to 'limit'. An error is returned if it
+// runs out of events (see maxEvents) first.
+func (w *wavefront) advance(limit float64) error {
+	for i := 0; ; i++ {
+		w.settle()
+		t := w.nextEvent()
+		if t > limit || math.IsInf(t, 1) {
+			break
+		}
+		if i == w.maxEvents {
+			return fmt.Errorf("after %v events at distance %v: %w", i, w.now, ErrSkeletonStalled)
+		}
+		w.now = t
+	}
+	if !math.IsInf(limit, 1) {
+		w.now = limit
+	}
+	return nil
+}
+
+// front returns the rings of the wavefront at the current time.
+func (w *wavefront) front() [][]vec2.T {
+	var r [][]vec2.T
+	seen := map[*wfVertex]bool{}
+	for _, v := range w.verts {
+		if !v.alive || seen[v] {
+			continue
+		}
+		var ring []vec2.T
+		for u := v; !seen[u]; u = u.next {
+			seen[u] = true
+			ring = append(ring, u.at(w.now))
+		}
+		r = append(r, ring)
+	}
+	return r
+}
+
+// cut records the faces swept by the edges up to the current time, so
+// that later faces start from here.
+func (w *wavefront) cut() {
+	for _, v := range w.verts {
+		if v.alive {
+			w.sweep(v)
+		}
+	}
+	w.base = w.now
+}
+
+// finish records the faces and arcs of the remaining wavefront up to the
+// current time and removes it.
+func (w *wavefront) finish() {
+	w.cut()
+	for _, v := range w.verts {
+		if v.alive {
+			w.end(v)
+		}
+	}
+}
+
+// nextEvent returns the time of the next edge or split event, or +Inf if
+// there is none.
+func (w *wavefront) nextEvent() float64 {
+	best := math.Inf(1)
+	for _, v := range w.verts {
+		if !v.alive {
+			continue
+		}
+		// Edge event: the edge from v to v.next shrinks to nothing.
+		u := v.next
+		pv, pu := v.at(w.now), u.at(w.now)
+		d := vec2.Sub(&pu, &pv)
+		dv := vec2.Sub(&u.vel, &v.vel)
+		if rate := vec2.Dot(&dv, &v.dir); rate < 0 {
+			l := math.Max(0, vec2.Dot(&d, &v.dir))
+			best = math.Min(best, w.now-l/rate)
+		}
+		if !v.reflex() {
+			continue
+		}
+		// Split event: the reflex vertex v runs into another edge.
+		for _, e := range w.verts {
+			if !e.alive || v.adjacent(e, w.now, w.eps) {
+				continue
+			}
+			n := vec2.T{-e.dir[1], e.dir[0]}
+			pe := e.at(w.now)
+			pd := vec2.Sub(&pv, &pe)
+			dist := vec2.Dot(&pd, &n)
+			speed := 1 - vec2.Dot(&v.vel, &n)
+			if dist <= w.eps || speed <= 1e-9 {
+				continue
+			}
+			t := w.now + dist/speed
+			if t >= best {
+				continue
+			}
+			if w.onEdge(v.at(t), e, t) {
+				best = t
+			}
+		}
+	}
+	return best
+}
+
+// onEdge reports whether the point lies on the edge from 'e' to e.next at
+// time 't'.
+func (w *wavefront) onEdge(p vec2.T, e *wfVertex, t float64) bool {
+	a, b := e.at(t), e.next.at(t)
+	ab := vec2.Sub(&b, &a)
+	ap := vec2.Sub(&p, &a)
+	s := vec2.Dot(&ap, &e.dir)
+	if s < -w.eps || s > vec2.Dot(&ab, &e.dir)+w.eps {
+		return false
+	}
+	return math.Abs(cross2(e.dir, ap)) <= w.eps
+}
+
+// settle handles all of the events that happen at the current time.
+func (w *wavefront) settle() {
+	for changed := true; changed; {
+		changed = false
+		for _, v := range w.verts {
+			if !v.alive {
+				continue
+			}
+			switch {
+			case v.next.next == v || v.next == v:
+				w.collapseRing(v)
+			case v.spike:
+				w.fold(v)
+			case distance(v.at(w.now), v.next.at(w.now)) <= w.eps:
+				w.merge(v)
+			default:
+				continue
+			}
+			changed = true
+		}
+		if changed {
+			continue
+		}
+		for _, v := range w.verts {
+			if !v.alive || !v.reflex() {
+				continue
+			}
+			p := v.at(w.now)
+			for _, e := range w.verts {
+				if !e.alive || v.adjacent(e, w.now, w.eps) || !w.onEdge(p, e, w.now) {
+					continue
+				}
+				n := vec2.T{-e.dir[1], e.dir[0]}
+				if 1-vec2.Dot(&v.vel, &n) <= 1e-9 {
+					continue
+				}
+				w.split(v, e)
+				changed = true
+				break
+			}
+			if changed {
+				break
+			}
+		}
+	}
+}
+
+// merge handles the edge event where the edge from 'v' to v.next has
+// shrunk to nothing, replacing both vertices with one.
+func (w *wavefront) merge(v *wfVertex) {
+	u := v.next
+	w.sweep(v.prev)
+	w.sweep(v)
+	w.sweep(u)
+	w.end(v)
+	w.end(u)
+	a, b := v.at(w.now), u.at(w.now)
+	m := w.newVertex(vec2.Interpolate(&a, &b, 0.5), u.dir, v.prev, u.next)
+	m.tops = u.tops
+	if m.next != m && m.next.next != m {
+		m.setVelocity()
+	}
+}
+
+// split handles the split event where the reflex vertex 'v' has run into
+// the edge from 'e' to e.next, dividing the edge in two and replacing 'v'
+// with a vertex on each side of it.
+func (w *wavefront) split(v, e *wfVertex) {
+	p := v.at(w.now)
+	w.sweep(v.prev)
+	w.sweep(v)
+	w.sweepTo(e, &p)
+	w.end(v)
+	prev, next, f := v.prev, v.next, e.next
+	a := w.newVertex(p, e.dir, prev, f)
+	b := w.newVertex(p, v.dir, e, next)
+	a.twin, b.twin = b, a
+	a.tops, b.tops = append([]int(nil), e.tops...), v.tops
+	for _, u := range []*wfVertex{a, b} {
+		if u.next != u && u.next.next != u {
+			u.setVelocity()
+		}
+	}
+}
+
+// fold removes the vertex 'v' whose edges double back on each other. The
+// overlapping parts of the edges enclose no solid, so they become a
+// ridge of the skeleton and the shorter edge is absorbed by the longer.
+func (w *wavefront) fold(v *wfVertex) {
+	prev, next := v.prev, v.next
+	p := v.at(w.now)
+	a, b := prev.at(w.now), next.at(w.now)
+	la, lb := distance(a, p), distance(b, p)
+	// The top of the longer edge's face is divided where the shorter
+	// edge ends.
+	switch {
+	case math.Abs(la-lb) <= w.eps:
+		w.sweep(prev)
+		w.sweep(v)
+	case la < lb:
+		w.sweep(prev)
+		w.sweepTo(v, &a)
+	default:
+		w.sweepTo(prev, &b)
+		w.sweep(v)
+	}
+	w.end(v)
+	switch {
+	case math.Abs(la-lb) <= w.eps:
+		// The edges vanish: join the vertices at their ends.
+		w.addArc(p, a)
+		w.sweep(prev.prev)
+		w.sweep(next)
+		w.end(prev)
+		w.end(next)
+		if prev.prev == next {
+			return // the whole ring has folded up
+		}
+		m := w.newVertex(vec2.Interpolate(&a, &b, 0.5), next.dir, prev.prev, next.next)
+		m.tops = next.tops
+		if m.next != m && m.next.next != m {
+			m.setVelocity()
+		}
+	case la < lb:
+		// 'prev' now lies on the edge to 'next'.
+		w.addArc(p, a)
+		w.sweep(prev.prev)
+		w.end(prev)
+		m := w.newVertex(a, v.dir, prev.prev, next)
+		m.tops = v.tops
+		m.setVelocity()
+	default:
+		// 'next' now lies on the edge from 'prev'.
+		w.addArc(p, b)
+		w.sweep(next)
+		w.end(next)
+		m := w.newVertex(b, next.dir, prev, next.next)
+		m.tops = next.tops
+		m.setVelocity()
+	}
+}
+
+// collapseRing removes a ring of one or two vertices, which has no area
+// left. The line between two vertices is a ridge of the skeleton.
+func (w *wavefront) collapseRing(v *wfVertex) {
+	u := v.next
+	w.sweep(v)
+	if u != v {
+		w.sweep(u)
+		w.addArc(v.at(w.now), u.at(w.now))
+		w.end(u)
+	}
+	w.end(v)
+}
+
+// addArc records the skeleton arc between two points at the current time.
+func (w *wavefront) addArc(a, b vec2.T) {
+	if distance(a, b) > w.eps {
+		w.arcs = append(w.arcs, [2]vec3.T{{a[0], a[1], w.now}, {b[0], b[1], w.now}})
+	}
+}
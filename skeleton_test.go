@@ -0,0 +1,259 @@
+package parametric2d
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+	"github.com/gmlewis/go3d/float64/vec3"
+)
+
+// flatArea returns the projected area of the triangles that lie flat at
+// height 'z'.
+func flatArea(tris []Triangle3D, z float64) float64 {
+	var area float64
+	for _, t := range tris {
+		if math.Abs(t[0][2]-z) < 1e-9 && math.Abs(t[1][2]-z) < 1e-9 && math.Abs(t[2][2]-z) < 1e-9 {
+			area += projectedArea([]Triangle3D{t})
+		}
+	}
+	return area
+}
+
+// maxZ returns the greatest height of the triangles.
+func maxZ(tris []Triangle3D) float64 {
+	z := math.Inf(-1)
+	for _, t := range tris {
+		for _, v := range t {
+			z = math.Max(z, v[2])
+		}
+	}
+	return z
+}
+
+func TestPathStraightSkeleton(t *testing.T) {
+	p := mustParseSVGPath(t, "M0 0h10v2h-10z")
+	arcs, err := p.StraightSkeleton(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][2]vec3.T{
+		{{0, 0, 0}, {1, 1, 1}},
+		{{10, 0, 0}, {9, 1, 1}},
+		{{10, 2, 0}, {9, 1, 1}},
+		{{0, 2, 0}, {1, 1, 1}},
+		{{1, 1, 1}, {9, 1, 1}},
+	}
+	if len(arcs) != len(want) {
+		t.Fatalf("StraightSkeleton = %v arcs, want %v: %v", len(arcs), len(want), arcs)
+	}
+	for _, w := range want {
+		found := false
+		for _, a := range arcs {
+			for _, pair := range [][2]vec3.T{a, {a[1], a[0]}} {
+				d0, d1 := vec3.Sub(&pair[0], &w[0]), vec3.Sub(&pair[1], &w[1])
+				if d0.Length() < 1e-9 && d1.Length() < 1e-9 {
+					found = true
+				}
+			}
+		}
+		if !found {
+			t.Errorf("StraightSkeleton = %v, missing arc %v", arcs, w)
+		}
+	}
+}
+
+// roof returns the whole roof over the solid bounded by the rings (each
+// with the solid on its left), sloping up at 'slope' from height 'z', and
+// the rings left at its top.
+func roof(rings [][]vec2.T, z, slope float64) ([]Triangle3D, [][]vec2.T, error) {
+	w := newWavefront(rings)
+	if err := w.advance(math.Inf(1)); err != nil {
+		return nil, nil, err
+	}
+	w.cut()
+	return w.slopedFaces(nil, 0, z, 0, slope), w.front(), nil
+}
+
+func TestRoof(t *testing.T) {
+	tests := []struct {
+		name  string
+		d     string
+		want  float64 // height of the roof
+		tol   float64
+		slope float64
+	}{
+		{"square", "M0 0h10v10h-10z", 5, 1e-9, 1},
+		{"tall roof", "M0 0h10v10h-10z", 10, 1e-9, 2},
+		{"T shape", "M0 0h10v2h-4v8h-2v-8h-4z", 1, 1e-9, 1},
+		{"L shape", "M0 0h6v2h-4v6h-2z", 1, 1e-9, 1},
+		{"plus", "M2 0h2v2h2v2h-2v2h-2v-2h-2v-2h2z", 1, 1e-9, 1},
+		{"square with hole", "M0 0h10v10h-10z M3 3v4h4v-4z", 1.5, 1e-9, 1},
+		{"four holes", "M0 0h20v20h-20z M2 2v7h7v-7z M11 2v7h7v-7z M2 11v7h7v-7z M11 11v7h7v-7z", 1, 1e-9, 1},
+		{"circle", "M10 0A10 10 0 0 1 -10 0A10 10 0 0 1 10 0z", 10, 0.1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustParseSVGPath(t, tt.d)
+			var tris []Triangle3D
+			var area float64
+			for _, g := range p.nest(10) {
+				rings := g.solidRings(10)
+				for _, ring := range rings {
+					area += polygonArea(ring)
+				}
+				roof, top, err := roof(rings, 0, tt.slope)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(top) != 0 {
+					t.Errorf("roof left %v rings at the top", len(top))
+				}
+				tris = append(tris, roof...)
+			}
+			if got := projectedArea(tris); math.Abs(got-area) > 1e-9 {
+				t.Errorf("projected area = %v, want %v", got, area)
+			}
+			if got := maxZ(tris); math.Abs(got-tt.want) > tt.tol {
+				t.Errorf("height = %v, want %v", got, tt.want)
+			}
+			for i, tri := range tris {
+				for _, v := range tri {
+					if math.IsNaN(v[2]) || math.IsInf(v[2], 0) {
+						t.Fatalf("triangle #%v %v has no height", i, tri)
+					}
+				}
+				if n := tri.Normal(); n[2] <= 0 {
+					t.Errorf("triangle #%v %v does not face up: %v", i, tri, n)
+				}
+			}
+		})
+	}
+}
+
+func TestWavefront_Stalled(t *testing.T) {
+	w := newWavefront([][]vec2.T{{{0, 0}, {10, 0}, {10, 2}, {0, 2}}})
+	w.maxEvents = 0
+	if err := w.advance(math.Inf(1)); !errors.Is(err, ErrSkeletonStalled) {
+		t.Errorf("advance = %v, want ErrSkeletonStalled", err)
+	}
+	if err := newWavefront([][]vec2.T{{{0, 0}, {10, 0}, {10, 2}, {0, 2}}}).advance(math.Inf(1)); err != nil {
+		t.Errorf("advance = %v, want no error", err)
+	}
+}
+
+func TestPathBevel_Skeleton(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        string
+		offset   float64
+		wantTop  float64 // area of the top of the bevel
+		wantArea float64 // projected area of the bevel and its top
+		wantZ    float64
+	}{
+		{"rectangle ridge", "M0 0h10v2h-10z", 3, 0, 20, 1},
+		{"square pyramid", "M0 0h10v10h-10z", 5, 0, 100, 5},
+		{"dumbbell", "M0 0h4v1h2v-1h4v4h-4v-1h-2v1h-4z", 1.5, 2, 36, 1.5},
+		{"hole meets outer", "M0 0h10v10h-10z M3 3v4h4v-4z", 2, 0, 84, 1.5},
+		{"hole offsets overlap", "M0 0h10v10h-10z M2 2v2h2v-2z M5 2v2h2v-2z", 1.2, 7.6*7.6 - 7*4, 92, 1.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustParseSVGPath(t, tt.d)
+			p.AutoFlipNormals()
+			tris, err := p.Bevel(0, tt.offset, 45, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := flatArea(tris, tt.offset); math.Abs(got-tt.wantTop) > 1e-9 {
+				t.Errorf("top area = %v, want %v", got, tt.wantTop)
+			}
+			if got := projectedArea(tris); math.Abs(got-tt.wantArea) > 1e-9 {
+				t.Errorf("projected area = %v, want %v", got, tt.wantArea)
+			}
+			if got := maxZ(tris); math.Abs(got-tt.wantZ) > 1e-9 {
+				t.Errorf("height = %v, want %v", got, tt.wantZ)
+			}
+		})
+	}
+}
+
+func TestSubPathBevel_Skeleton(t *testing.T) {
+	tests := []struct {
+		name      string
+		d         string
+		offset    float64
+		wantPts   int
+		wantCap   float64
+		wantRoofZ float64
+	}{
+		{"rectangle ridge", "M0 0h10v2h-10z", 3, 0, 0, 1},
+		{"dumbbell", "M0 0h4v1h2v-1h4v4h-4v-1h-2v1h-4z", 1.5, 0, 2, 1.5},
+		{"notched", "M0 0h10v4h-4v-3h-2v3h-4z", 1.5, 0, 2, 1.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := mustParseSVGPath(t, tt.d).SubPaths[0]
+			sp.AutoFlipNormals()
+			tris, err := sp.Bevel(0, tt.offset, 45, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(sp.BevelPts); got != tt.wantPts {
+				t.Errorf("BevelPts = %v points, want %v", got, tt.wantPts)
+			}
+			if got := flatArea(tris, tt.offset); math.Abs(got-tt.wantCap) > 1e-9 {
+				t.Errorf("cap area = %v, want %v", got, tt.wantCap)
+			}
+			if got := maxZ(tris); math.Abs(got-tt.wantRoofZ) > 1e-9 {
+				t.Errorf("height = %v, want %v", got, tt.wantRoofZ)
+			}
+			if got, want := projectedArea(tris), math.Abs(sp.SignedArea()); math.Abs(got-want) > 1e-9 {
+				t.Errorf("projected area = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPathBevel_NarrowPoints(t *testing.T) {
+	// The points of the star are narrower than twice the offset, but the
+	// trimmed offset of the star is still a single loop.
+	p := mustParseSVGPath(t, "M5 0L6.2 3.5L10 3.5L7 5.8L8.1 9.5L5 7.2L1.9 9.5L3 5.8L0 3.5L3.8 3.5z")
+	p.AutoFlipNormals()
+	tris, err := p.Bevel(0, 1, 45, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := projectedArea(tris), math.Abs(p.SubPaths[0].SignedArea()); math.Abs(got-want) > 1e-9 {
+		t.Errorf("projected area = %v, want %v", got, want)
+	}
+	for i, tri := range tris {
+		if n := tri.Normal(); n[2] < 0 {
+			t.Errorf("triangle #%v %v faces down: %v", i, tri, n)
+		}
+	}
+}
+
+func TestSubPathBevel_Repeated(t *testing.T) {
+	// A hole whose mouth closes up, leaving an island of solid inside it.
+	sp := mustParseSVGPath(t, "M0 0h10v10h-10v-4.5h2v2.5h6v-6h-6v2.5h-2z").SubPaths[0]
+	sp.AutoFlipNormals()
+	sp.FlipNormals = !sp.FlipNormals
+	want := -1
+	for i := 0; i < 2; i++ {
+		tris, err := sp.Bevel(0, 1, 45, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := flatArea(tris, 1); math.Abs(got-16) > 1e-9 {
+			t.Errorf("Bevel #%v cap area = %v, want 16", i+1, got)
+		}
+		if want < 0 {
+			want = len(sp.BevelPts)
+		}
+		if got := len(sp.BevelPts); got == 0 || got != want {
+			t.Errorf("Bevel #%v BevelPts = %v points, want %v", i+1, got, want)
+		}
+	}
+}
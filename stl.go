@@ -0,0 +1,159 @@
+package parametric2d
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	stlHeaderSize = 80
+	stlFacetSize  = 50
+)
+
+// BinarySTLWriter writes triangles in the binary STL format and
+// implements TriangleWriter. The triangle count in the header is
+// back-patched by Close, so the underlying writer must be seekable.
+type BinarySTLWriter struct {
+	w     io.WriteSeeker
+	start int64
+	count uint32
+}
+
+// NewBinarySTLWriter writes a binary STL header to 'w' and returns
+// a writer for the triangles that follow it.
+func NewBinarySTLWriter(w io.WriteSeeker) (*BinarySTLWriter, error) {
+	start, err := w.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeSTLHeader(w, 0); err != nil {
+		return nil, err
+	}
+	return &BinarySTLWriter{w: w, start: start}, nil
+}
+
+// WriteTriangle3D writes a single facet with its computed normal.
+func (s *BinarySTLWriter) WriteTriangle3D(t Triangle3D) error {
+	if err := writeSTLFacet(s.w, t); err != nil {
+		return err
+	}
+	s.count++
+	return nil
+}
+
+// Close patches the triangle count into the header and leaves
+// the underlying writer positioned at the end of the data.
+func (s *BinarySTLWriter) Close() error {
+	end, err := s.w.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := s.w.Seek(s.start+stlHeaderSize, io.SeekStart); err != nil {
+		return err
+	}
+	if err := binary.Write(s.w, binary.LittleEndian, s.count); err != nil {
+		return err
+	}
+	_, err = s.w.Seek(end, io.SeekStart)
+	return err
+}
+
+// WriteBinarySTL writes all triangles in the binary STL format.
+// Since the number of triangles is known up front, 'w' need not be seekable.
+func WriteBinarySTL(w io.Writer, tris []Triangle3D) error {
+	if err := writeSTLHeader(w, uint32(len(tris))); err != nil {
+		return err
+	}
+	for _, t := range tris {
+		if err := writeSTLFacet(w, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSTLHeader(w io.Writer, count uint32) error {
+	var header [stlHeaderSize + 4]byte
+	copy(header[:], "binary STL generated by parametric2d")
+	binary.LittleEndian.PutUint32(header[stlHeaderSize:], count)
+	_, err := w.Write(header[:])
+	return err
+}
+
+func writeSTLFacet(w io.Writer, t Triangle3D) error {
+	var buf [stlFacetSize]byte
+	n := t.Normal()
+	putFloat32 := func(i int, v float64) {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(float32(v)))
+	}
+	for i := 0; i < 3; i++ {
+		putFloat32(i, n[i])
+	}
+	for j, v := range t[:3] {
+		for i := 0; i < 3; i++ {
+			putFloat32(3+3*j+i, v[i])
+		}
+	}
+	// The final two bytes (attribute byte count) are left as zero.
+	_, err := w.Write(buf[:])
+	return err
+}
+
+// ASCIISTLWriter writes triangles in the ASCII STL format and
+// implements TriangleWriter. Close must be called to end the solid.
+type ASCIISTLWriter struct {
+	w    io.Writer
+	name string
+}
+
+// NewASCIISTLWriter begins a solid named 'name' on 'w' and returns
+// a writer for its triangles.
+func NewASCIISTLWriter(w io.Writer, name string) (*ASCIISTLWriter, error) {
+	if _, err := fmt.Fprintf(w, "solid %v\n", name); err != nil {
+		return nil, err
+	}
+	return &ASCIISTLWriter{w: w, name: name}, nil
+}
+
+// WriteTriangle3D writes a single facet with its computed normal.
+func (s *ASCIISTLWriter) WriteTriangle3D(t Triangle3D) error {
+	n := t.Normal()
+	ew := &errWriter{w: s.w}
+	ew.printf("facet normal %v %v %v\n  outer loop\n", n[0], n[1], n[2])
+	for _, v := range t[:3] {
+		ew.printf("    vertex %v %v %v\n", v[0], v[1], v[2])
+	}
+	ew.printf("  endloop\nendfacet\n")
+	return ew.err
+}
+
+// Close ends the solid.
+func (s *ASCIISTLWriter) Close() error {
+	_, err := fmt.Fprintf(s.w, "endsolid %v\n", s.name)
+	return err
+}
+
+// WriteASCIISTL writes all triangles as an ASCII STL solid named 'name'.
+func WriteASCIISTL(w io.Writer, name string, tris []Triangle3D) error {
+	s, err := NewASCIISTLWriter(w, name)
+	if err != nil {
+		return err
+	}
+	if err := WriteTriangles(s, tris); err != nil {
+		return err
+	}
+	return s.Close()
+}
+
+// WriteTriangles streams all triangles (such as the results of
+// Path.Wall and Path.Bevel) to the TriangleWriter.
+func WriteTriangles(tw TriangleWriter, tris []Triangle3D) error {
+	for _, t := range tris {
+		if err := tw.WriteTriangle3D(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
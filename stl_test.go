@@ -0,0 +1,110 @@
+package parametric2d
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec3"
+)
+
+var _ TriangleWriter = &BinarySTLWriter{}
+var _ TriangleWriter = &ASCIISTLWriter{}
+
+// seekBuffer is an in-memory io.WriteSeeker.
+type seekBuffer struct {
+	buf []byte
+	pos int
+}
+
+func (s *seekBuffer) Write(p []byte) (int, error) {
+	if n := s.pos + len(p); n > len(s.buf) {
+		s.buf = append(s.buf, make([]byte, n-len(s.buf))...)
+	}
+	copy(s.buf[s.pos:], p)
+	s.pos += len(p)
+	return len(p), nil
+}
+
+func (s *seekBuffer) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		s.pos = int(offset)
+	case io.SeekCurrent:
+		s.pos += int(offset)
+	case io.SeekEnd:
+		s.pos = len(s.buf) + int(offset)
+	}
+	return int64(s.pos), nil
+}
+
+var stlTestTris = []Triangle3D{
+	{vec3.T{0, 0, 0}, vec3.T{1, 0, 0}, vec3.T{0, 1, 0}},
+	{vec3.T{0, 0, 0}, vec3.T{0, 0, 1}, vec3.T{1, 0, 0}},
+}
+
+func checkBinarySTL(t *testing.T, b []byte) {
+	t.Helper()
+	if want := stlHeaderSize + 4 + stlFacetSize*len(stlTestTris); len(b) != want {
+		t.Fatalf("binary STL is %v bytes, want %v", len(b), want)
+	}
+	if got := binary.LittleEndian.Uint32(b[stlHeaderSize:]); got != uint32(len(stlTestTris)) {
+		t.Errorf("triangle count = %v, want %v", got, len(stlTestTris))
+	}
+	wantNormals := []vec3.T{{0, 0, 1}, {0, 1, 0}}
+	for i, want := range wantNormals {
+		facet := b[stlHeaderSize+4+i*stlFacetSize:]
+		for j := 0; j < 3; j++ {
+			got := math.Float32frombits(binary.LittleEndian.Uint32(facet[4*j:]))
+			if float64(got) != want[j] {
+				t.Errorf("facet #%v normal[%v] = %v, want %v", i, j, got, want[j])
+			}
+		}
+	}
+}
+
+func TestBinarySTLWriter(t *testing.T) {
+	sb := &seekBuffer{}
+	w, err := NewBinarySTLWriter(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteTriangles(w, stlTestTris); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if sb.pos != len(sb.buf) {
+		t.Errorf("Close left writer at %v, want %v", sb.pos, len(sb.buf))
+	}
+	checkBinarySTL(t, sb.buf)
+}
+
+func TestWriteBinarySTL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBinarySTL(&buf, stlTestTris); err != nil {
+		t.Fatal(err)
+	}
+	checkBinarySTL(t, buf.Bytes())
+}
+
+func TestWriteASCIISTL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteASCIISTL(&buf, "test", stlTestTris); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	if !strings.HasPrefix(got, "solid test\n") || !strings.HasSuffix(got, "endsolid test\n") {
+		t.Errorf("WriteASCIISTL missing solid/endsolid:\n%v", got)
+	}
+	if n := strings.Count(got, "endfacet"); n != 2 {
+		t.Errorf("WriteASCIISTL wrote %v facets, want 2", n)
+	}
+	if !strings.Contains(got, "facet normal 0 1 0\n") {
+		t.Errorf("WriteASCIISTL missing facet normal:\n%v", got)
+	}
+}
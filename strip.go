@@ -0,0 +1,80 @@
+package parametric2d
+
+import (
+	"math"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+	"github.com/gmlewis/go3d/float64/vec3"
+)
+
+// stitchRings joins two closed rings of points that run in the same
+// direction with a strip of triangles. Starting from the points that are
+// closest together, the strip advances along whichever ring gives the
+// shorter diagonal. The triangles face to the right of the rings, which
+// is away from the solid when the rings have the solid on their left, and
+// face up when 'top' lies above 'bottom'.
+func stitchRings(bottom, top []vec3.T) []Triangle3D {
+	n, m := len(bottom), len(top)
+	if n == 0 || m == 0 {
+		return nil
+	}
+	j0, best := 0, -1.0
+	for j, p := range top {
+		d := vec3.Sub(&p, &bottom[0])
+		if l := d.LengthSqr(); best < 0 || l < best {
+			j0, best = j, l
+		}
+	}
+	r := make([]Triangle3D, 0, n+m)
+	i, j := 0, 0
+	for i < n || j < m {
+		b0, b1 := bottom[i%n], bottom[(i+1)%n]
+		t0, t1 := top[(j0+j)%m], top[(j0+j+1)%m]
+		advanceBottom := j == m
+		if i < n && j < m {
+			d0 := vec3.Sub(&b1, &t0)
+			d1 := vec3.Sub(&t1, &b0)
+			advanceBottom = d0.LengthSqr() <= d1.LengthSqr()
+		}
+		if advanceBottom {
+			r = append(r, Triangle3D{b0, b1, t0})
+			i++
+		} else {
+			r = append(r, Triangle3D{b0, t1, t0})
+			j++
+		}
+	}
+	return r
+}
+
+// bevelStrip stitches the ring 'base' at height 'z0' to the ring 'top'
+// at height 'z1' (see stitchRings). It returns false if the strip folds
+// over itself, which happens when parts of the base have no counterpart
+// in the top because the top has closed up there: the strip then covers
+// more than the area between the rings.
+func bevelStrip(base, top []vec2.T, z0, z1 float64) ([]Triangle3D, bool) {
+	r := stitchRings(ring3D(base, z0), ring3D(top, z1))
+	var covered float64
+	for _, t := range r {
+		a, b, c := vec2.T{t[0][0], t[0][1]}, vec2.T{t[1][0], t[1][1]}, vec2.T{t[2][0], t[2][1]}
+		covered += 0.5 * math.Abs(cross3(a, b, c))
+	}
+	a0, a1 := polygonArea(base), polygonArea(top)
+	return r, math.Abs(covered-(a0-a1)) <= 1e-9*(math.Abs(a0)+math.Abs(a1))
+}
+
+// ring3D returns the 2D points at height 'z'.
+func ring3D(pts []vec2.T, z float64) []vec3.T {
+	r := make([]vec3.T, len(pts))
+	for i, p := range pts {
+		r[i] = vec3.T{p[0], p[1], z}
+	}
+	return r
+}
+
+// reversePoints reverses the points in place.
+func reversePoints(pts []vec2.T) {
+	for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+		pts[i], pts[j] = pts[j], pts[i]
+	}
+}
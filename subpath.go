@@ -1,6 +1,7 @@
 package parametric2d
 
 import (
+	"errors"
 	"fmt"
 	"math"
 
@@ -33,69 +34,269 @@ func (s *SubPath) BBox() vec2.Rect {
 	return bbox
 }
 
+// Flatten returns the closed polygon approximating the SubPath, where each
+// segment is subdivided such that the tangent between two points never
+// exceeds `maxDegrees`. The first point is not repeated at the end.
+func (s *SubPath) Flatten(maxDegrees float64) []vec2.T {
+	var r []vec2.T
+	for _, seg := range s.Segments {
+		for _, t := range subdivisionTs(seg, maxDegrees)[1:] {
+			r = append(r, seg.At(t))
+		}
+	}
+	return r
+}
+
+// Length returns the total arc length of the SubPath.
+func (s *SubPath) Length() float64 {
+	var total float64
+	for _, seg := range s.Segments {
+		total += seg.Length()
+	}
+	return total
+}
+
+// AtLength returns the index of the segment and its parametric 't' value
+// at the given arc length along the SubPath.
+func (s *SubPath) AtLength(l float64) (int, float64) {
+	if len(s.Segments) == 0 {
+		return -1, 0
+	}
+	for i, seg := range s.Segments {
+		segLen := seg.Length()
+		if l <= segLen || i == len(s.Segments)-1 {
+			return i, seg.AtLength(l)
+		}
+		l -= segLen
+	}
+	return len(s.Segments) - 1, 1
+}
+
+// ClosestPoint returns the index of the segment and the parametric 't'
+// value of the point on the SubPath nearest to 'p', along with its
+// distance from 'p'. Segments whose bounding boxes are farther away than
+// the best point found so far are skipped. The index is -1 if the SubPath
+// has no segments.
+func (s *SubPath) ClosestPoint(p vec2.T) (int, float64, float64) {
+	bboxes := make([]vec2.Rect, len(s.Segments))
+	for i, seg := range s.Segments {
+		bboxes[i] = seg.BBox()
+	}
+	bestSeg, bestT, best := -1, 0.0, math.Inf(1)
+	for _, i := range byBBoxDistance(bboxes, p) {
+		if bboxDistance(bboxes[i], p) >= best {
+			break
+		}
+		if t, d := s.Segments[i].ClosestPoint(p); d < best {
+			bestSeg, bestT, best = i, t, d
+		}
+	}
+	return bestSeg, bestT, best
+}
+
+// ErrInvalidDraft is returned when a draft angle is not less than 90
+// degrees in either direction.
+var ErrInvalidDraft = errors.New("parametric2d: invalid draft angle")
+
 // Wall extrudes a subpath into a 3D wall. `maxDegrees` determines the smoothness
-// of the wall along the subpath.
-func (s *SubPath) Wall(height, maxDegrees float64) []Triangle3D {
+// of the wall along the subpath. FloorPts is replaced by the base of the wall.
+func (s *SubPath) Wall(height, maxDegrees float64) ([]Triangle3D, error) {
 	s.FloorZ = 0
+	s.FloorPts = nil
 	r := make([]Triangle3D, 0, 100)
-	for _, seg := range s.Segments {
-		w, floorPts := seg.Wall(height, maxDegrees, s.FlipNormals)
+	for i, seg := range s.Segments {
+		w, floorPts, err := seg.Wall(height, maxDegrees, s.FlipNormals)
+		if err != nil {
+			return nil, fmt.Errorf("segment %v: %w", i, err)
+		}
 		r = append(r, w...)
 		s.FloorPts = append(s.FloorPts, floorPts...)
-		// fmt.Printf("GML: subPath floorPts=%#v\n", floorPts)
 	}
-	return r
+	return r, nil
 }
 
-// Bevel returns a 3D beveled object based on the provided subpath.
-func (s *SubPath) Bevel(height, offset, deg, maxDegrees float64) []Triangle3D {
-	s.BevelZ = height + offset*math.Tan(deg*math.Pi/180.0)
-	r := []Triangle3D{}
-	fmt.Printf("\nGML: ENTER Subpath.Bevel: #Segments=%v", len(s.Segments))
+// DraftWall extrudes a subpath into a 3D wall that rises 'height' from its
+// base at 'z' and leans toward the side its normals face by 'draft' degrees
+// from vertical (or away from it if 'draft' is negative), so that walls can
+// taper as needed for molds and cookie cutters. The top of the wall is
+// offset along the normals with the corners mitered as in the segment Bevel
+// methods. FloorPts is replaced by the base of the wall, at FloorZ. An
+// error is returned if a segment is degenerate or its normal is undefined.
+func (s *SubPath) DraftWall(z, height, draft, maxDegrees float64) ([]Triangle3D, error) {
+	if math.Abs(draft) >= 90 {
+		return nil, fmt.Errorf("draft of %v degrees: %w", draft, ErrInvalidDraft)
+	}
+	s.FloorZ = z
+	s.FloorPts = nil
+	offset := height * math.Tan(draft*math.Pi/180.0)
+	r := make([]Triangle3D, 0, 100)
 	for i, seg := range s.Segments {
-		j := (i + len(s.Segments) - 1) % len(s.Segments)
-		k := (i + 1) % len(s.Segments)
-		prevNN := s.Segments[j].NNormal(1)
-		nextNN := s.Segments[k].NNormal(0)
-		if s.FlipNormals {
-			prevNN[0], prevNN[1], nextNN[0], nextNN[1] = -prevNN[0], -prevNN[1], -nextNN[0], -nextNN[1]
+		prevNN, nextNN := s.neighborNormals(i)
+		ts := subdivisionTs(seg, maxDegrees)
+		w, _, err := miterBand(seg, ts, z, z+height, offset, s.FlipNormals, &prevNN, &nextNN)
+		if err != nil {
+			return nil, fmt.Errorf("segment %v: %w", i, err)
 		}
-		{ //GML... debug
-			n0 := s.Segments[i].NNormal(0)
-			n1 := s.Segments[i].NNormal(1)
-			fmt.Printf("GML: j=%v, prevNN=%v, i=%v, n0=%v, n1=%v, k=%v, nextNN=%v\n", j, prevNN, i, n0, n1, k, nextNN)
+		r = append(r, w...)
+		for _, t := range ts[1:] {
+			p := seg.At(t)
+			s.FloorPts = append(s.FloorPts, poly2tri.NewPoint(p[0], p[1]))
 		}
-		b, bevelPts := seg.Bevel(height, offset, deg, maxDegrees, s.FlipNormals, &prevNN, &nextNN)
-		r = append(r, b...)
-		s.BevelPts = append(s.BevelPts, bevelPts...)
-		// fmt.Printf("GML: subPath bevelPts={")
-		// for _, v := range bevelPts {
-		// 	fmt.Printf("%v ", v)
-		// }
-		// fmt.Printf("}\n")
+	}
+	return r, nil
+}
+
+// neighborNormals returns the (possibly flipped) normalized normals of the
+// segments before and after segment 'i' where they meet it.
+func (s *SubPath) neighborNormals(i int) (prevNN, nextNN vec2.T) {
+	n := len(s.Segments)
+	prevNN = s.Segments[(i+n-1)%n].NNormal(1)
+	nextNN = s.Segments[(i+1)%n].NNormal(0)
+	if s.FlipNormals {
+		prevNN[0], prevNN[1], nextNN[0], nextNN[1] = -prevNN[0], -prevNN[1], -nextNN[0], -nextNN[1]
+	}
+	return prevNN, nextNN
+}
+
+// ErrSplitBevel is returned by SubPath.Bevel when the top of the bevel
+// comes apart into several pieces that surround the solid, which cannot
+// be held in BevelPts as a single polygon.
+var ErrSplitBevel = errors.New("parametric2d: bevel top split around the solid")
+
+// Bevel returns a 3D beveled object based on the provided subpath.
+//
+// The top of the bevel is the offset of the subpath (see Offset) with its
+// cusps and self-overlaps trimmed away, so that tight concave curves do
+// not fold the bevel over itself. If the offset is so large that parts of
+// the subpath are narrower than twice the offset, the bevel instead
+// follows the roof built over the subpath's straight skeleton (see
+// Path.StraightSkeleton), which closes up over those parts and meets in a
+// ridge if all of the subpath is that narrow. BevelPts is replaced by the
+// top of the bevel if it is a single polygon; otherwise the pieces of the
+// top that enclose the solid are capped in the returned triangles and
+// BevelPts holds the piece around them, if any. ErrSplitBevel is returned
+// if there is more than one such piece.
+func (s *SubPath) Bevel(height, offset, deg, maxDegrees float64) ([]Triangle3D, error) {
+	pts, err := LinearProfile(offset, deg).points(maxDegrees)
+	if err != nil {
+		return nil, err
+	}
+	s.BevelZ = height + pts[len(pts)-1][1]
+	s.BevelPts = nil
+	if logger.enabled() {
+		logger.Debug("SubPath.Bevel", "segments", len(s.Segments))
+	}
+	r, tops, offsets, err := (&subPathGroup{outer: s}).bevelRings(nil, pts, height, maxDegrees, nil)
+	if err != nil {
+		return nil, err
+	}
+	if offsets || len(tops) == 1 {
+		s.addBevelPts(tops[0])
+		return r, nil
+	}
+	var around [][]vec2.T
+	for _, top := range tops {
+		if polygonArea(top) > 0 {
+			r = capRings(r, [][]vec2.T{top}, s.BevelZ, maxDegrees)
+		} else {
+			around = append(around, top)
+		}
+	}
+	if len(around) > 1 {
+		return nil, fmt.Errorf("%v pieces around the top: %w", len(around), ErrSplitBevel)
+	}
+	if len(around) == 1 {
+		s.addBevelPts(around[0])
+	}
+	return r, nil
+}
+
+// addBevelPts appends the points to BevelPts.
+func (s *SubPath) addBevelPts(pts []vec2.T) {
+	for _, p := range pts {
+		s.BevelPts = append(s.BevelPts, poly2tri.NewPoint(p[0], p[1]))
+	}
+}
+
+// solidRing returns the flattened SubPath ordered so that the side its
+// normals face is on its left.
+func (s *SubPath) solidRing(maxDegrees float64) []vec2.T {
+	ring := s.Flatten(maxDegrees)
+	if s.FlipNormals {
+		reversePoints(ring)
+	}
+	return ring
+}
+
+// Orientation describes the winding direction of a closed SubPath.
+type Orientation int
+
+const (
+	// Clockwise subpaths have a negative signed area.
+	Clockwise Orientation = -1
+	// Collinear subpaths enclose no area.
+	Collinear Orientation = 0
+	// CounterClockwise subpaths have a positive signed area.
+	CounterClockwise Orientation = 1
+)
+
+// orientationMaxDegrees is the subdivision used to flatten subpaths
+// when computing their area and orientation.
+const orientationMaxDegrees = 5
+
+// SignedArea returns the area enclosed by the SubPath using the shoelace
+// formula over its flattened segments. It is positive when the SubPath
+// winds counter-clockwise.
+func (s *SubPath) SignedArea() float64 {
+	return polygonArea(s.Flatten(orientationMaxDegrees))
+}
+
+// Orientation returns the winding direction of the SubPath.
+func (s *SubPath) Orientation() Orientation {
+	switch a := s.SignedArea(); {
+	case a > 0:
+		return CounterClockwise
+	case a < 0:
+		return Clockwise
+	}
+	return Collinear
+}
+
+// Reverse reverses the direction of the SubPath in place. FlipNormals is
+// toggled so that the normals continue to face the same side of the
+// SubPath.
+func (s *SubPath) Reverse() {
+	n := len(s.Segments)
+	for i := 0; i < n/2; i++ {
+		s.Segments[i], s.Segments[n-1-i] = s.Segments[n-1-i], s.Segments[i]
+	}
+	for i, seg := range s.Segments {
+		s.Segments[i] = seg.Reverse()
+	}
+	s.FlipNormals = !s.FlipNormals
+}
+
+// Transform returns a copy of the SubPath transformed by 'm'. Mirror
+// transformations reverse the direction of the SubPath, so FlipNormals is
+// toggled to keep the normals facing the same (transformed) side.
+func (s *SubPath) Transform(m Affine) *SubPath {
+	r := &SubPath{
+		Segments:    make([]T, len(s.Segments)),
+		FlipNormals: s.FlipNormals != m.IsMirror(),
+		IsOuter:     s.IsOuter,
+	}
+	for i, seg := range s.Segments {
+		r.Segments[i] = seg.Transform(m)
 	}
 	return r
 }
 
-// AutoFlipNormals analyzes the subpath to determine if its normals
-// are currently facing the correct direction (outward), and if not,
-// sets the 'FlipNormals' flag on the subpath.
+// AutoFlipNormals determines the orientation of the subpath from its
+// signed area and sets the 'FlipNormals' flag so that its normals face
+// the interior of the subpath (the side that Bevel offsets toward and
+// that the Wall triangles face away from).
 func (s *SubPath) AutoFlipNormals() {
-	bbox := s.BBox()
-	flippedBBox := bbox
-	for _, sp := range s.Segments {
-		p0 := sp.At(0)
-		p1 := sp.At(1)
-		n0 := sp.NNormal(0)
-		n1 := sp.NNormal(1)
-		newP0 := vec2.Add(&p0, &n0)
-		newP1 := vec2.Add(&p1, &n1)
-		b := vec2.NewRect(&newP0, &newP1)
-		flippedBBox = vec2.Joined(&flippedBBox, &b)
-	}
-	if bbox.Contains(&flippedBBox) {
-		return
-	}
-	// fmt.Printf("setting flip normals=true, bbox=%v, flippedBBox=%v\n", bbox, flippedBBox)
-	s.FlipNormals = true
+	// The (unflipped) normal points to the left of the direction of travel,
+	// which is the interior of a counter-clockwise subpath.
+	s.FlipNormals = s.Orientation() == Clockwise
 }
@@ -0,0 +1,201 @@
+package parametric2d
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+	"github.com/gmlewis/go3d/float64/vec3"
+)
+
+// circleSubPath returns a circle of the given radius made of four arcs
+// whose endpoints lie at 45 degrees, away from the extremes of its bbox.
+func circleSubPath(center vec2.T, r float64, ccw bool) *SubPath {
+	sweep := 90.0
+	if !ccw {
+		sweep = -90
+	}
+	sp := &SubPath{}
+	for i := 0; i < 4; i++ {
+		sp.Segments = append(sp.Segments, NewArc(center, r, r, 0, 45+float64(i)*sweep, sweep))
+	}
+	return sp
+}
+
+// normalsPointInward reports whether the (possibly flipped) normal at the
+// midpoint of every segment points toward 'inside'.
+func normalsPointInward(sp *SubPath, inside vec2.T) bool {
+	for _, seg := range sp.Segments {
+		n := seg.NNormal(0.5)
+		if sp.FlipNormals {
+			n.Invert()
+		}
+		p := seg.At(0.5)
+		d := vec2.Sub(&inside, &p)
+		if vec2.Dot(&n, &d) <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSubPathOrientation(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        string
+		wantArea float64
+		want     Orientation
+	}{
+		{"ccw square", "M0 0h10v10h-10z", 100, CounterClockwise},
+		{"cw square", "M0 0v10h10v-10z", -100, Clockwise},
+		{"cw U shape", "M0 0v10h3v-7h4v7h3v-10z", -72, Clockwise},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := mustParseSVGPath(t, tt.d).SubPaths[0]
+			if got := sp.SignedArea(); math.Abs(got-tt.wantArea) > 1e-9 {
+				t.Errorf("SignedArea = %v, want %v", got, tt.wantArea)
+			}
+			if got := sp.Orientation(); got != tt.want {
+				t.Errorf("Orientation = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubPathAutoFlipNormals(t *testing.T) {
+	center := vec2.T{20, 20}
+	for _, ccw := range []bool{true, false} {
+		// The normals at the arc endpoints do not leave the circle's bbox
+		// in either orientation, which defeats a bbox-based heuristic.
+		sp := circleSubPath(center, 10, ccw)
+		sp.AutoFlipNormals()
+		if !normalsPointInward(sp, center) {
+			t.Errorf("ccw=%v: normals do not point inward, FlipNormals=%v", ccw, sp.FlipNormals)
+		}
+	}
+
+	// A tiny shape whose normals would be nudged out of its bbox.
+	sp := mustParseSVGPath(t, "M0 0h0.1v0.1h-0.1z").SubPaths[0]
+	sp.AutoFlipNormals()
+	if !normalsPointInward(sp, vec2.T{0.05, 0.05}) {
+		t.Errorf("tiny square: normals do not point inward, FlipNormals=%v", sp.FlipNormals)
+	}
+}
+
+func TestSubPathReverse(t *testing.T) {
+	sp := circleSubPath(vec2.T{0, 0}, 1, true)
+	start := sp.Segments[0].At(0)
+	end := sp.Segments[3].At(1)
+	sp.Reverse()
+	if got := sp.Orientation(); got != Clockwise {
+		t.Errorf("Orientation = %v, want Clockwise", got)
+	}
+	if !sp.FlipNormals {
+		t.Errorf("FlipNormals = false, want true")
+	}
+	if got := sp.Segments[0].At(0); !vecNear(got, end, 1e-12) {
+		t.Errorf("reversed start = %v, want %v", got, end)
+	}
+	if got := sp.Segments[3].At(1); !vecNear(got, start, 1e-12) {
+		t.Errorf("reversed end = %v, want %v", got, start)
+	}
+	if !normalsPointInward(sp, vec2.T{0, 0}) {
+		t.Errorf("Reverse changed the side the normals face")
+	}
+}
+
+func TestPathAutoFlipNormals(t *testing.T) {
+	// Both contours are drawn counter-clockwise.
+	p := &Path{SubPaths: []*SubPath{
+		circleSubPath(vec2.T{0, 0}, 4, true),
+		circleSubPath(vec2.T{0, 0}, 10, true),
+	}}
+	p.AutoFlipNormals()
+	outer, hole := p.SubPaths[0], p.SubPaths[1]
+	if !outer.IsOuter || hole.IsOuter {
+		t.Fatalf("IsOuter = %v, %v, want true, false", outer.IsOuter, hole.IsOuter)
+	}
+	if !normalsPointInward(outer, vec2.T{0, 0}) {
+		t.Errorf("outer normals do not face the solid")
+	}
+	if normalsPointInward(hole, vec2.T{0, 0}) {
+		t.Errorf("hole normals do not face the solid")
+	}
+	if outer.FlipNormals == hole.FlipNormals {
+		t.Errorf("FlipNormals = %v for both outer and hole", outer.FlipNormals)
+	}
+}
+
+func TestSubPathDraftWall(t *testing.T) {
+	square := func() *SubPath {
+		// Drawn clockwise, so that its normals must be flipped.
+		return mustParseSVGPath(t, "M0 0v10h10v-10z").SubPaths[0]
+	}
+	tests := []struct {
+		name   string
+		sp     *SubPath
+		draft  float64
+		top    float64 // distance of the top of the wall from the center
+		bottom float64 // distance of the base of the wall from the center
+	}{
+		{name: "vertical square", sp: square(), draft: 0, top: 5, bottom: 5},
+		{name: "inward square", sp: square(), draft: 45, top: 3, bottom: 5},
+		{name: "outward square", sp: square(), draft: -30, top: 5 + 2*math.Tan(math.Pi/6), bottom: 5},
+		{name: "inward circle", sp: circleSubPath(vec2.T{5, 5}, 5, false), draft: 45, top: 3, bottom: 5},
+	}
+
+	center := vec3.T{5, 5, 0}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.sp.AutoFlipNormals()
+			tris, err := tt.sp.DraftWall(1, 2, tt.draft, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.sp.FloorZ != 1 || len(tt.sp.FloorPts) == 0 {
+				t.Errorf("FloorZ = %v with %v FloorPts, want 1 with some", tt.sp.FloorZ, len(tt.sp.FloorPts))
+			}
+			n := len(tt.sp.FloorPts)
+			if _, err := tt.sp.DraftWall(1, 2, tt.draft, 10); err != nil || len(tt.sp.FloorPts) != n {
+				t.Errorf("DraftWall again = %v with %v FloorPts, want %v", err, len(tt.sp.FloorPts), n)
+			}
+			for _, tri := range tris {
+				for _, v := range tri {
+					want := tt.bottom
+					if v[2] == 3 {
+						want = tt.top
+					} else if v[2] != 1 {
+						t.Fatalf("vertex %v not at z=1 or z=3", v)
+					}
+					// Points on a square are as far from its center as along the farther axis.
+					d := math.Max(math.Abs(v[0]-5), math.Abs(v[1]-5))
+					if _, ok := tt.sp.Segments[0].(Arc); ok {
+						d = math.Hypot(v[0]-5, v[1]-5)
+					}
+					if math.Abs(d-want) > 1e-6 {
+						t.Fatalf("vertex %v is %v from the center, want %v", v, d, want)
+					}
+				}
+				// Each triangle faces away from the solid, and up if the
+				// wall leans in.
+				a, b, c := tri[0], tri[1], tri[2]
+				ab, ac := vec3.Sub(&b, &a), vec3.Sub(&c, &a)
+				n := vec3.Cross(&ab, &ac)
+				out := vec3.Sub(&a, &center)
+				out[2] = 0
+				if vec3.Dot(&n, &out) <= 0 {
+					t.Errorf("triangle %v faces the solid", tri)
+				}
+				if tt.draft > 0 && n[2] <= 0 || tt.draft < 0 && n[2] >= 0 || tt.draft == 0 && math.Abs(n[2]) > 1e-9 {
+					t.Errorf("triangle %v normal %v does not lean with the draft", tri, n)
+				}
+			}
+		})
+	}
+
+	if _, err := square().DraftWall(0, 1, 90, 10); !errors.Is(err, ErrInvalidDraft) {
+		t.Errorf("DraftWall(draft=90) = %v, want ErrInvalidDraft", err)
+	}
+}
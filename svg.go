@@ -0,0 +1,177 @@
+package parametric2d
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/gmlewis/go-poly2tri"
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// SVGOptions controls what WriteSVG renders.
+type SVGOptions struct {
+	// MaxDegrees determines the subdivision points at which normals
+	// and tangents are drawn (and how unknown segment types are flattened).
+	// Defaults to 10.
+	MaxDegrees float64
+	// ShowNormals draws the (possibly flipped) normal at each subdivision point.
+	ShowNormals bool
+	// ShowTangents draws the tangent at each subdivision point.
+	ShowTangents bool
+	// VectorLength is the drawn length of normals and tangents.
+	// Defaults to 5% of the diagonal of the Path's bounding box.
+	VectorLength float64
+	// ShowBevelPts draws the offset bevel polygon accumulated by Bevel.
+	ShowBevelPts bool
+	// ShowFloorPts draws the floor polygon accumulated by Wall.
+	ShowFloorPts bool
+	// StrokeWidth is the width of all drawn lines.
+	// Defaults to 0.2% of the diagonal of the Path's bounding box.
+	StrokeWidth float64
+}
+
+// WriteSVG renders the Path as an SVG document for debugging purposes.
+// The y axis points up as in the Path's own coordinate system.
+// 'opts' may be nil, in which case only the segments are drawn.
+func WriteSVG(w io.Writer, p *Path, opts *SVGOptions) error {
+	if opts == nil {
+		opts = &SVGOptions{}
+	}
+	maxDegrees := opts.MaxDegrees
+	if maxDegrees <= 0 {
+		maxDegrees = 10
+	}
+	bbox := p.BBox()
+	diag := vec2.Sub(&bbox.Max, &bbox.Min)
+	size := diag.Length()
+	if size == 0 {
+		size = 1
+	}
+	vecLen := opts.VectorLength
+	if vecLen <= 0 {
+		vecLen = 0.05 * size
+	}
+	strokeWidth := opts.StrokeWidth
+	if strokeWidth <= 0 {
+		strokeWidth = 0.002 * size
+	}
+	margin := vecLen + 0.02*size
+
+	ew := &errWriter{w: w}
+	ew.printf(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	ew.printf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="%v %v %v %v">`+"\n",
+		bbox.Min[0]-margin, -bbox.Max[1]-margin, diag[0]+2*margin, diag[1]+2*margin)
+	ew.printf(`<g transform="scale(1,-1)" fill="none" stroke-width="%v">`+"\n", strokeWidth)
+	for i, sp := range p.SubPaths {
+		ew.printf(`<path id="subpath%v" stroke="black" d="%v"/>`+"\n", i, svgPathData(sp, maxDegrees))
+		if opts.ShowFloorPts && len(sp.FloorPts) > 0 {
+			ew.printf(`<polygon id="floor%v" stroke="blue" points="%v"/>`+"\n", i, svgPoints(sp.FloorPts))
+		}
+		if opts.ShowBevelPts && len(sp.BevelPts) > 0 {
+			ew.printf(`<polygon id="bevel%v" stroke="red" points="%v"/>`+"\n", i, svgPoints(sp.BevelPts))
+		}
+		if !opts.ShowNormals && !opts.ShowTangents {
+			continue
+		}
+		for _, seg := range sp.Segments {
+			for _, t := range subdivisionTs(seg, maxDegrees) {
+				p0 := seg.At(t)
+				if opts.ShowNormals {
+					n := seg.NNormal(t)
+					if sp.FlipNormals {
+						n.Invert()
+					}
+					ew.svgVector("green", p0, n, vecLen)
+				}
+				if opts.ShowTangents {
+					ew.svgVector("orange", p0, seg.NTangent(t), vecLen)
+				}
+			}
+		}
+	}
+	ew.printf("</g>\n</svg>\n")
+	return ew.err
+}
+
+// subdivisionTs returns the parametric 't' values at which a segment is
+// subdivided by Wall and Bevel.
+func subdivisionTs(seg T, maxDegrees float64) []float64 {
+	if seg.IsLine() {
+		return []float64{0, 1}
+	}
+	if a, ok := seg.(Arc); ok {
+		return a.Subdivide(maxDegrees)
+	}
+	return subdivide(seg, maxDegrees)
+}
+
+// svgPathData returns the SVG path data for a closed SubPath.
+func svgPathData(sp *SubPath, maxDegrees float64) string {
+	if len(sp.Segments) == 0 {
+		return ""
+	}
+	p0 := sp.Segments[0].At(0)
+	d := fmt.Sprintf("M%v,%v", p0[0], p0[1])
+	for _, seg := range sp.Segments {
+		switch s := seg.(type) {
+		case Line:
+			d += fmt.Sprintf(" L%v,%v", s.p1[0], s.p1[1])
+		case Curve:
+			d += fmt.Sprintf(" C%v,%v %v,%v %v,%v", s.spline.P1[0], s.spline.P1[1],
+				s.spline.P2[0], s.spline.P2[1], s.spline.P3[0], s.spline.P3[1])
+		case QuadCurve:
+			d += fmt.Sprintf(" Q%v,%v %v,%v", s.spline.P1[0], s.spline.P1[1], s.spline.P2[0], s.spline.P2[1])
+		case Arc:
+			// A single SVG arc command cannot describe a full turn,
+			// so each arc is drawn as two halves.
+			for _, t := range []float64{0.5, 1} {
+				p := s.At(t)
+				sweep := 0
+				if s.sweep > 0 {
+					sweep = 1
+				}
+				d += fmt.Sprintf(" A%v,%v %v 0,%v %v,%v", s.rx, s.ry, s.Rotation(), sweep, p[0], p[1])
+			}
+		default:
+			for _, t := range subdivisionTs(seg, maxDegrees)[1:] {
+				p := seg.At(t)
+				d += fmt.Sprintf(" L%v,%v", p[0], p[1])
+			}
+		}
+	}
+	return d + " Z"
+}
+
+func svgPoints(pts poly2tri.PointArray) string {
+	var s string
+	for i, pt := range pts {
+		if i > 0 {
+			s += " "
+		}
+		s += fmt.Sprintf("%v,%v", pt.X, pt.Y)
+	}
+	return s
+}
+
+// errWriter remembers the first write error so that output can be
+// emitted without checking every call.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...interface{}) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = fmt.Fprintf(ew.w, format, args...)
+}
+
+func (ew *errWriter) svgVector(color string, p, dir vec2.T, length float64) {
+	if math.IsNaN(dir[0]) || math.IsNaN(dir[1]) {
+		return
+	}
+	ew.printf(`<line stroke="%v" x1="%v" y1="%v" x2="%v" y2="%v"/>`+"\n",
+		color, p[0], p[1], p[0]+length*dir[0], p[1]+length*dir[1])
+}
@@ -0,0 +1,74 @@
+package parametric2d
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteSVG(t *testing.T) {
+	p, err := ParseSVGPath("M0 0 L10 0 Q10 10 0 10 A5 5 0 0 1 0 0 Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, p, nil); err != nil {
+		t.Fatalf("WriteSVG: %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg"`,
+		`d="M0,0 L10,0 Q10,10 0,10 A5,5 0 0,1`,
+		"</svg>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteSVG output missing %q:\n%v", want, got)
+		}
+	}
+	if strings.Contains(got, "<line") {
+		t.Errorf("WriteSVG drew vectors without being asked:\n%v", got)
+	}
+}
+
+func TestWriteSVG_Options(t *testing.T) {
+	p, err := ParseSVGPath("M0 0 L10 0 L10 10 L0 10 Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.SubPaths[0].Bevel(0, 1, 45, 10); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	opts := &SVGOptions{ShowNormals: true, ShowTangents: true, ShowBevelPts: true}
+	if err := WriteSVG(&buf, p, opts); err != nil {
+		t.Fatalf("WriteSVG: %v", err)
+	}
+	got := buf.String()
+	// 4 lines, each with 2 subdivision points, with a normal and a tangent at each.
+	if n := strings.Count(got, "<line"); n != 16 {
+		t.Errorf("WriteSVG drew %v vectors, want 16", n)
+	}
+	if !strings.Contains(got, `<polygon id="bevel0"`) {
+		t.Errorf("WriteSVG output missing bevel polygon:\n%v", got)
+	}
+	if strings.Contains(got, `<polygon id="floor0"`) {
+		t.Errorf("WriteSVG drew empty floor polygon:\n%v", got)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteSVG_Error(t *testing.T) {
+	p, err := ParseSVGPath("M0 0 L10 0 L10 10 Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteSVG(failingWriter{}, p, nil); err == nil {
+		t.Errorf("WriteSVG = nil, want error")
+	}
+}
@@ -0,0 +1,370 @@
+package parametric2d
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+// SVGPathError reports a syntax error in SVG path data.
+type SVGPathError struct {
+	// Offset is the byte offset into the path data where the error occurred.
+	Offset int
+	Msg    string
+}
+
+func (e *SVGPathError) Error() string {
+	return fmt.Sprintf("svg path: offset %v: %v", e.Offset, e.Msg)
+}
+
+// ParseSVGPath parses SVG path data (the "d" attribute of a <path> element)
+// and returns a Path with one SubPath per contour.
+//
+// The full path grammar is supported: M, L, H, V, C, S, Q, T, A and Z in
+// both absolute (upper case) and relative (lower case) forms, including
+// implicit repetition of commands. Because a SubPath is always treated
+// as a closed contour, open subpaths are closed with a straight line.
+// Zero-length segments are dropped.
+func ParseSVGPath(d string) (*Path, error) {
+	p := &svgPathParser{data: d}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.path, nil
+}
+
+type svgPathParser struct {
+	data string
+	pos  int
+
+	path     *Path
+	segments []T
+	start    vec2.T // start of the current subpath
+	cur      vec2.T // current point
+	// ctrl is the last control point of the previous C/S or Q/T command
+	// and is used to compute the reflected control point for S and T.
+	ctrl    vec2.T
+	lastCmd byte
+}
+
+func (p *svgPathParser) errorf(offset int, format string, args ...interface{}) error {
+	return &SVGPathError{Offset: offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *svgPathParser) parse() error {
+	p.path = &Path{}
+	p.skipSeparators()
+	if p.pos < len(p.data) && p.data[p.pos] != 'M' && p.data[p.pos] != 'm' {
+		return p.errorf(p.pos, "path data must begin with a moveto command, got %q", p.data[p.pos])
+	}
+	for {
+		p.skipSeparators()
+		if p.pos >= len(p.data) {
+			break
+		}
+		offset := p.pos
+		cmd := p.data[p.pos]
+		if !isSVGCommand(cmd) {
+			return p.errorf(offset, "unexpected character %q", cmd)
+		}
+		p.pos++
+		if err := p.command(cmd); err != nil {
+			return err
+		}
+	}
+	p.closeSubPath()
+	return nil
+}
+
+// command parses the arguments of a single command and all of its
+// implicit repetitions.
+func (p *svgPathParser) command(cmd byte) error {
+	rel := cmd >= 'a' && cmd <= 'z'
+	upper := cmd &^ 0x20
+	if upper == 'Z' {
+		p.closeSubPath()
+		p.cur = p.start
+		p.lastCmd = 'Z'
+		return nil
+	}
+	for first := true; ; first = false {
+		if !first && !p.moreArgs() {
+			return nil
+		}
+		var origin vec2.T
+		if rel {
+			origin = p.cur
+		}
+		switch upper {
+		case 'M':
+			pt, err := p.point(origin)
+			if err != nil {
+				return err
+			}
+			if first {
+				p.closeSubPath()
+				p.start = pt
+				p.cur = pt
+				p.lastCmd = 'M'
+				continue
+			}
+			p.lineTo(pt)
+		case 'L':
+			pt, err := p.point(origin)
+			if err != nil {
+				return err
+			}
+			p.lineTo(pt)
+		case 'H':
+			x, err := p.number()
+			if err != nil {
+				return err
+			}
+			p.lineTo(vec2.T{origin[0] + x, p.cur[1]})
+		case 'V':
+			y, err := p.number()
+			if err != nil {
+				return err
+			}
+			p.lineTo(vec2.T{p.cur[0], origin[1] + y})
+		case 'C':
+			pts, err := p.points(origin, 3)
+			if err != nil {
+				return err
+			}
+			p.cubicTo(pts[0], pts[1], pts[2])
+		case 'S':
+			pts, err := p.points(origin, 2)
+			if err != nil {
+				return err
+			}
+			p1 := p.cur
+			if p.lastCmd == 'C' || p.lastCmd == 'S' {
+				p1 = vec2.T{2*p.cur[0] - p.ctrl[0], 2*p.cur[1] - p.ctrl[1]}
+			}
+			p.cubicTo(p1, pts[0], pts[1])
+		case 'Q':
+			pts, err := p.points(origin, 2)
+			if err != nil {
+				return err
+			}
+			p.quadTo(pts[0], pts[1])
+		case 'T':
+			pt, err := p.point(origin)
+			if err != nil {
+				return err
+			}
+			p1 := p.cur
+			if p.lastCmd == 'Q' || p.lastCmd == 'T' {
+				p1 = vec2.T{2*p.cur[0] - p.ctrl[0], 2*p.cur[1] - p.ctrl[1]}
+			}
+			p.quadTo(p1, pt)
+		case 'A':
+			if err := p.arc(origin); err != nil {
+				return err
+			}
+		}
+		p.lastCmd = upper
+	}
+}
+
+func (p *svgPathParser) lineTo(pt vec2.T) {
+	if pt != p.cur {
+		p.segments = append(p.segments, NewLine(p.cur, pt))
+	}
+	p.cur = pt
+}
+
+func (p *svgPathParser) cubicTo(p1, p2, p3 vec2.T) {
+	switch {
+	case p.cur == p3 && p1 == p3 && p2 == p3:
+	case (p.cur == p1 && p2 == p3) || (p.cur == p1 && p1 == p2) || (p1 == p2 && p2 == p3):
+		// Control points coincide with endpoints; this is a straight line.
+		if p.cur != p3 {
+			p.segments = append(p.segments, NewLine(p.cur, p3))
+		}
+	default:
+		p.segments = append(p.segments, NewCurve(p.cur, p1, p2, p3))
+	}
+	p.ctrl = p2
+	p.cur = p3
+}
+
+func (p *svgPathParser) quadTo(p1, p2 vec2.T) {
+	switch {
+	case p.cur == p2 && p1 == p2:
+	case p.cur == p1 || p1 == p2:
+		if p.cur != p2 {
+			p.segments = append(p.segments, NewLine(p.cur, p2))
+		}
+	default:
+		p.segments = append(p.segments, NewQuadCurve(p.cur, p1, p2))
+	}
+	p.ctrl = p1
+	p.cur = p2
+}
+
+func (p *svgPathParser) arc(origin vec2.T) error {
+	rx, err := p.number()
+	if err != nil {
+		return err
+	}
+	ry, err := p.number()
+	if err != nil {
+		return err
+	}
+	rotation, err := p.number()
+	if err != nil {
+		return err
+	}
+	largeArc, err := p.flag()
+	if err != nil {
+		return err
+	}
+	sweep, err := p.flag()
+	if err != nil {
+		return err
+	}
+	pt, err := p.point(origin)
+	if err != nil {
+		return err
+	}
+	if seg := NewSVGArc(p.cur, rx, ry, rotation, largeArc, sweep, pt); seg != nil {
+		p.segments = append(p.segments, seg)
+	}
+	p.cur = pt
+	return nil
+}
+
+// closeSubPath closes the current contour (if any) with a straight line
+// and appends it to the path.
+func (p *svgPathParser) closeSubPath() {
+	if len(p.segments) == 0 {
+		return
+	}
+	p.lineTo(p.start)
+	p.path.SubPaths = append(p.path.SubPaths, &SubPath{Segments: p.segments})
+	p.segments = nil
+}
+
+func (p *svgPathParser) points(origin vec2.T, n int) ([]vec2.T, error) {
+	pts := make([]vec2.T, n)
+	for i := range pts {
+		pt, err := p.point(origin)
+		if err != nil {
+			return nil, err
+		}
+		pts[i] = pt
+	}
+	return pts, nil
+}
+
+func (p *svgPathParser) point(origin vec2.T) (vec2.T, error) {
+	x, err := p.number()
+	if err != nil {
+		return vec2.T{}, err
+	}
+	y, err := p.number()
+	if err != nil {
+		return vec2.T{}, err
+	}
+	return vec2.T{origin[0] + x, origin[1] + y}, nil
+}
+
+// number parses a floating point number, preceded by optional separators.
+func (p *svgPathParser) number() (float64, error) {
+	p.skipSeparators()
+	start := p.pos
+	i := p.pos
+	if i < len(p.data) && (p.data[i] == '+' || p.data[i] == '-') {
+		i++
+	}
+	digits := 0
+	for i < len(p.data) && isDigit(p.data[i]) {
+		i++
+		digits++
+	}
+	if i < len(p.data) && p.data[i] == '.' {
+		i++
+		for i < len(p.data) && isDigit(p.data[i]) {
+			i++
+			digits++
+		}
+	}
+	if digits == 0 {
+		if start >= len(p.data) {
+			return 0, p.errorf(start, "unexpected end of path data, expected number")
+		}
+		return 0, p.errorf(start, "expected number, got %q", p.data[start])
+	}
+	if i < len(p.data) && (p.data[i] == 'e' || p.data[i] == 'E') {
+		j := i + 1
+		if j < len(p.data) && (p.data[j] == '+' || p.data[j] == '-') {
+			j++
+		}
+		if j < len(p.data) && isDigit(p.data[j]) {
+			for j < len(p.data) && isDigit(p.data[j]) {
+				j++
+			}
+			i = j
+		}
+	}
+	v, err := strconv.ParseFloat(p.data[start:i], 64)
+	if err != nil {
+		return 0, p.errorf(start, "invalid number %q", p.data[start:i])
+	}
+	p.pos = i
+	return v, nil
+}
+
+// flag parses a single-character arc flag ('0' or '1').
+func (p *svgPathParser) flag() (bool, error) {
+	p.skipSeparators()
+	if p.pos >= len(p.data) {
+		return false, p.errorf(p.pos, "unexpected end of path data, expected flag")
+	}
+	switch p.data[p.pos] {
+	case '0':
+		p.pos++
+		return false, nil
+	case '1':
+		p.pos++
+		return true, nil
+	}
+	return false, p.errorf(p.pos, "expected flag '0' or '1', got %q", p.data[p.pos])
+}
+
+// moreArgs reports whether another set of arguments follows,
+// signaling an implicit repetition of the previous command.
+func (p *svgPathParser) moreArgs() bool {
+	p.skipSeparators()
+	if p.pos >= len(p.data) {
+		return false
+	}
+	c := p.data[p.pos]
+	return isDigit(c) || c == '+' || c == '-' || c == '.'
+}
+
+func (p *svgPathParser) skipSeparators() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\n', '\r', '\f', ',':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isSVGCommand(c byte) bool {
+	switch c &^ 0x20 {
+	case 'M', 'Z', 'L', 'H', 'V', 'C', 'S', 'Q', 'T', 'A':
+		return true
+	}
+	return false
+}
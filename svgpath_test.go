@@ -0,0 +1,128 @@
+package parametric2d
+
+import (
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec2"
+)
+
+func segmentTypes(sp *SubPath) string {
+	var s string
+	for _, seg := range sp.Segments {
+		switch seg.(type) {
+		case Line:
+			s += "L"
+		case Curve:
+			s += "C"
+		case QuadCurve:
+			s += "Q"
+		case Arc:
+			s += "A"
+		default:
+			s += "?"
+		}
+	}
+	return s
+}
+
+func TestParseSVGPath(t *testing.T) {
+	tests := []struct {
+		name string
+		d    string
+		want []string // segment types per subpath
+		end  vec2.T   // end point of the last segment of the first subpath
+	}{
+		{"absolute", "M0 0 L10 0 L10 10 L0 10 Z", []string{"LLLL"}, vec2.T{0, 0}},
+		{"relative", "m0,0h10v10h-10z", []string{"LLLL"}, vec2.T{0, 0}},
+		{"implicit lineto", "M0 0 10 0 10 10 0 10z", []string{"LLLL"}, vec2.T{0, 0}},
+		{"implicit relative lineto", "m1 1 9 0 0 9z", []string{"LLL"}, vec2.T{1, 1}},
+		{"implicit close", "M0 0 L10 0 L10 10", []string{"LLL"}, vec2.T{0, 0}},
+		{"closing point repeated", "M0 0 L10 0 L10 10 L0 0 Z", []string{"LLL"}, vec2.T{0, 0}},
+		{"two contours", "M0 0h10v10h-10z M2 2h6v6h-6z", []string{"LLLL", "LLLL"}, vec2.T{0, 0}},
+		{"draw after close", "M0 0h10v10z h-5v-5z", []string{"LLL", "LLL"}, vec2.T{0, 0}},
+		{"cubic and smooth", "M0 0 C0 1 1 1 1 0 S2 -1 2 0 z", []string{"CCL"}, vec2.T{0, 0}},
+		{"quad and smooth", "M0 0 Q1 1 2 0 T4 0 z", []string{"QQL"}, vec2.T{0, 0}},
+		{"arc with packed flags", "M0 0a1 1 0 00 1 1z", []string{"AL"}, vec2.T{0, 0}},
+		{"exponents and signs", "M1e1-1E0L.5.5-5e-1+2z", []string{"LLL"}, vec2.T{10, -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParseSVGPath(tt.d)
+			if err != nil {
+				t.Fatalf("ParseSVGPath(%q): %v", tt.d, err)
+			}
+			if len(p.SubPaths) != len(tt.want) {
+				t.Fatalf("ParseSVGPath(%q) = %v subpaths, want %v", tt.d, len(p.SubPaths), len(tt.want))
+			}
+			for i, sp := range p.SubPaths {
+				if got := segmentTypes(sp); got != tt.want[i] {
+					t.Errorf("subpath #%v = %q, want %q", i, got, tt.want[i])
+				}
+			}
+			sp := p.SubPaths[0]
+			if got := sp.Segments[len(sp.Segments)-1].At(1); !vecNear(got, tt.end, 1e-12) {
+				t.Errorf("end point = %v, want %v", got, tt.end)
+			}
+		})
+	}
+}
+
+func TestParseSVGPath_Smooth(t *testing.T) {
+	p, err := ParseSVGPath("M0 0 C0 1 1 1 1 0 S2 -1 2 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The reflected control point makes the join smooth.
+	a := p.SubPaths[0].Segments[0].NTangent(1)
+	b := p.SubPaths[0].Segments[1].NTangent(0)
+	if !vecNear(a, b, 1e-12) {
+		t.Errorf("S tangent = %v, want %v", b, a)
+	}
+}
+
+func TestParseSVGPath_SmoothAfterLine(t *testing.T) {
+	p, err := ParseSVGPath("M0 0 L1 0 S3 1 3 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without a previous curve, the first control point is the current point.
+	c, ok := p.SubPaths[0].Segments[1].(Curve)
+	if !ok {
+		t.Fatalf("segment = %T, want Curve", p.SubPaths[0].Segments[1])
+	}
+	p0, p1, p2, p3 := c.Points()
+	if want := [4]vec2.T{{1, 0}, {1, 0}, {3, 1}, {3, 0}}; [4]vec2.T{p0, p1, p2, p3} != want {
+		t.Errorf("control points = %v, want %v", [4]vec2.T{p0, p1, p2, p3}, want)
+	}
+	if _, err := Offset(c, 0.1, DefaultOffsetTolerance); err != nil {
+		t.Errorf("Offset = %v", err)
+	}
+}
+
+func TestParseSVGPath_Errors(t *testing.T) {
+	tests := []struct {
+		d      string
+		offset int
+	}{
+		{"L0 0", 0},
+		{"M0 0 L10 x", 9},
+		{"M0 0 L10", 8},
+		{"M0 0 A1 1 0 2 1 1 1", 12},
+		{"M0 0 X1 1", 5},
+	}
+	for _, tt := range tests {
+		_, err := ParseSVGPath(tt.d)
+		if err == nil {
+			t.Errorf("ParseSVGPath(%q) = nil error, want error", tt.d)
+			continue
+		}
+		e, ok := err.(*SVGPathError)
+		if !ok {
+			t.Errorf("ParseSVGPath(%q) = %T, want *SVGPathError", tt.d, err)
+			continue
+		}
+		if e.Offset != tt.offset {
+			t.Errorf("ParseSVGPath(%q) offset = %v, want %v (%v)", tt.d, e.Offset, tt.offset, err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package parametric2d
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gmlewis/go3d/float64/vec3"
+)
+
+// MeshEdge identifies an edge of a triangle mesh and the triangles using it.
+type MeshEdge struct {
+	A, B vec3.T
+	// Triangles holds the indices of the input triangles that use this edge.
+	Triangles []int
+}
+
+// MeshReport describes the defects found by ValidateMesh.
+type MeshReport struct {
+	// BoundaryEdges are used by only one triangle (holes in the surface).
+	BoundaryEdges []MeshEdge
+	// NonManifoldEdges are shared by more than two triangles.
+	NonManifoldEdges []MeshEdge
+	// InconsistentEdges are shared by two triangles that traverse the edge
+	// in the same direction, meaning that their windings disagree.
+	InconsistentEdges []MeshEdge
+	// DegenerateTriangles holds the indices of triangles with (nearly) zero area.
+	DegenerateTriangles []int
+}
+
+// IsWatertight returns true if every edge is shared by exactly two triangles.
+func (r *MeshReport) IsWatertight() bool {
+	return len(r.BoundaryEdges) == 0 && len(r.NonManifoldEdges) == 0
+}
+
+// IsValid returns true if no defects were found.
+func (r *MeshReport) IsValid() bool {
+	return r.IsWatertight() && len(r.InconsistentEdges) == 0 && len(r.DegenerateTriangles) == 0
+}
+
+// String summarizes the report.
+func (r *MeshReport) String() string {
+	return fmt.Sprintf("%v boundary edges, %v non-manifold edges, %v inconsistent edges, %v degenerate triangles",
+		len(r.BoundaryEdges), len(r.NonManifoldEdges), len(r.InconsistentEdges), len(r.DegenerateTriangles))
+}
+
+// ValidateMesh checks that the triangles (such as the combined output of
+// Path.Wall and Path.Bevel) form a closed, manifold, consistently wound
+// surface. Vertices within 'epsilon' of each other are considered identical
+// and triangles whose area is below epsilon squared are reported as
+// degenerate and excluded from the edge checks.
+func ValidateMesh(tris []Triangle3D, epsilon float64) *MeshReport {
+	r := &MeshReport{}
+	m := &Mesh{}
+	w := newWelder(epsilon)
+
+	type use struct {
+		tri     int
+		forward bool
+	}
+	edges := map[[2]int][]use{}
+	minArea := epsilon * epsilon
+	for i, t := range tris {
+		var f [3]int
+		for j, v := range t[:3] {
+			f[j] = w.index(m, v)
+		}
+		a := vec3.Sub(&t[1], &t[0])
+		b := vec3.Sub(&t[2], &t[0])
+		c := vec3.Cross(&a, &b)
+		if f[0] == f[1] || f[1] == f[2] || f[2] == f[0] || 0.5*c.Length() <= minArea {
+			r.DegenerateTriangles = append(r.DegenerateTriangles, i)
+			continue
+		}
+		for j := 0; j < 3; j++ {
+			v0, v1 := f[j], f[(j+1)%3]
+			key, forward := [2]int{v0, v1}, true
+			if v1 < v0 {
+				key, forward = [2]int{v1, v0}, false
+			}
+			edges[key] = append(edges[key], use{tri: i, forward: forward})
+		}
+	}
+
+	keys := make([][2]int, 0, len(edges))
+	for k := range edges {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, k := range keys {
+		uses := edges[k]
+		e := MeshEdge{A: m.Vertices[k[0]], B: m.Vertices[k[1]]}
+		for _, u := range uses {
+			e.Triangles = append(e.Triangles, u.tri)
+		}
+		switch {
+		case len(uses) == 1:
+			r.BoundaryEdges = append(r.BoundaryEdges, e)
+		case len(uses) > 2:
+			r.NonManifoldEdges = append(r.NonManifoldEdges, e)
+		case uses[0].forward == uses[1].forward:
+			r.InconsistentEdges = append(r.InconsistentEdges, e)
+		}
+	}
+	return r
+}
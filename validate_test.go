@@ -0,0 +1,52 @@
+package parametric2d
+
+import (
+	"testing"
+
+	"github.com/gmlewis/go3d/float64/vec3"
+)
+
+// tetrahedron returns a closed, outward-facing tetrahedron.
+func tetrahedron() []Triangle3D {
+	a, b, c, d := vec3.T{0, 0, 0}, vec3.T{1, 0, 0}, vec3.T{0, 1, 0}, vec3.T{0, 0, 1}
+	return []Triangle3D{
+		{a, c, b},
+		{a, b, d},
+		{b, c, d},
+		{c, a, d},
+	}
+}
+
+func TestValidateMesh(t *testing.T) {
+	flipped := tetrahedron()
+	flipped[0][1], flipped[0][2] = flipped[0][2], flipped[0][1]
+	dup := append(tetrahedron(), tetrahedron()[1])
+	degenerate := append(tetrahedron(), Triangle3D{vec3.T{0, 0, 0}, vec3.T{1, 0, 0}, vec3.T{2, 0, 0}})
+	nudged := tetrahedron()
+	nudged[3][2] = vec3.T{1e-9, 0, 1}
+
+	tests := []struct {
+		name                                        string
+		tris                                        []Triangle3D
+		boundary, nonManifold, inconsistent, degens int
+	}{
+		{"closed", tetrahedron(), 0, 0, 0, 0},
+		{"missing face", tetrahedron()[1:], 3, 0, 0, 0},
+		{"flipped face", flipped, 0, 0, 3, 0},
+		{"duplicate face", dup, 0, 3, 0, 0},
+		{"degenerate", degenerate, 0, 0, 0, 1},
+		{"welded", nudged, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ValidateMesh(tt.tris, 1e-6)
+			if len(r.BoundaryEdges) != tt.boundary || len(r.NonManifoldEdges) != tt.nonManifold ||
+				len(r.InconsistentEdges) != tt.inconsistent || len(r.DegenerateTriangles) != tt.degens {
+				t.Errorf("ValidateMesh = %v, want %v/%v/%v/%v", r, tt.boundary, tt.nonManifold, tt.inconsistent, tt.degens)
+			}
+			if want := tt.boundary == 0 && tt.nonManifold == 0; r.IsWatertight() != want {
+				t.Errorf("IsWatertight = %v, want %v", r.IsWatertight(), want)
+			}
+		})
+	}
+}